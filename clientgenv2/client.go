@@ -54,6 +54,12 @@ func (p *Plugin) MutateConfig(cfg *config.Config) error {
 		if err != nil {
 			return fmt.Errorf(": %w", err)
 		}
+
+		if p.GenerateConfig.ShouldAddCacheKeyFields() {
+			if err := querydocument.AddCacheKeyFields(cfg.Schema, queryDocument); err != nil {
+				return fmt.Errorf("add cache key fields failed: %w", err)
+			}
+		}
 	}
 
 	var err error
@@ -85,8 +91,31 @@ func (p *Plugin) MutateConfig(cfg *config.Config) error {
 		return fmt.Errorf("generating operation failed: %w", err)
 	}
 
-	if err := RenderTemplate(cfg, fragments, operations, operationResponses, source.ResponseSubTypes(), p.GenerateConfig, p.Client); err != nil {
-		return fmt.Errorf("template failed: %w", err)
+	if p.GenerateConfig.GetSplit() != "" {
+		if err := RenderSplitTemplates(cfg, fragments, operations, operationResponses, source.ResponseSubTypes(), p.GenerateConfig, p.Client); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+	} else {
+		if err := RenderTemplate(cfg, fragments, operations, operationResponses, source.ResponseSubTypes(), p.GenerateConfig, p.Client); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+
+		// the operations of a previous generation with split would be declared twice
+		if err := RemoveStaleSplitFiles(p.Client.Filename, nil); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+	}
+
+	if mockConfig := p.GenerateConfig.GetMock(); mockConfig != nil {
+		if err := RenderMockTemplate(cfg, operations, p.GenerateConfig, mockConfig, p.Client); err != nil {
+			return fmt.Errorf("mock template failed: %w", err)
+		}
+	}
+
+	if manifestConfig := p.GenerateConfig.GetPersistedQueryManifest(); manifestConfig != nil {
+		if err := WritePersistedQueryManifest(operations, manifestConfig); err != nil {
+			return fmt.Errorf("persisted query manifest failed: %w", err)
+		}
 	}
 
 	return nil
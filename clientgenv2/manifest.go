@@ -0,0 +1,69 @@
+package clientgenv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gqlgencConfig "github.com/Yamashou/gqlgenc/config"
+)
+
+// https://www.apollographql.com/docs/graphos/routing/security/persisted-queries#manifest-format
+const apolloManifestFormat = "apollo-persisted-query-manifest"
+
+type apolloManifest struct {
+	Format     string                    `json:"format"`
+	Version    int                       `json:"version"`
+	Operations []apolloManifestOperation `json:"operations"`
+}
+
+type apolloManifestOperation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Body string `json:"body"`
+}
+
+// WritePersistedQueryManifest writes the documents of the operations keyed by the hash the generated client sends as their id.
+func WritePersistedQueryManifest(operations []*Operation, manifestConfig *gqlgencConfig.PersistedQueryManifestConfig) error {
+	var manifest any
+	switch manifestConfig.Format {
+	case gqlgencConfig.PersistedQueryManifestFormatRelay:
+		documents := make(map[string]string, len(operations))
+		for _, operation := range operations {
+			documents[operation.DocumentHash] = operation.Operation
+		}
+		manifest = documents
+	default:
+		apollo := apolloManifest{
+			Format:     apolloManifestFormat,
+			Version:    1,
+			Operations: make([]apolloManifestOperation, 0, len(operations)),
+		}
+		for _, operation := range operations {
+			apollo.Operations = append(apollo.Operations, apolloManifestOperation{
+				ID:   operation.DocumentHash,
+				Name: operation.Name,
+				Type: string(operation.OperationType),
+				Body: operation.Operation,
+			})
+		}
+		manifest = apollo
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestConfig.Filename), 0o755); err != nil {
+		return fmt.Errorf("create manifest directory: %w", err)
+	}
+
+	if err := os.WriteFile(manifestConfig.Filename, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	return nil
+}
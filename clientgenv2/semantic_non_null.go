@@ -0,0 +1,96 @@
+package clientgenv2
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// semanticNonNullLevels returns the levels of the @semanticNonNull directive of definition, 0 being the field itself
+// and 1 the items of its list, or nil when the field does not have the directive.
+func semanticNonNullLevels(definition *ast.FieldDefinition) []int {
+	directive := definition.Directives.ForName("semanticNonNull")
+	if directive == nil {
+		return nil
+	}
+
+	argument := directive.Arguments.ForName("levels")
+	if argument == nil || argument.Value == nil {
+		return []int{0}
+	}
+
+	var levels []int
+	for _, child := range argument.Value.Children {
+		if level, err := strconv.Atoi(child.Value.Raw); err == nil {
+			levels = append(levels, level)
+		}
+	}
+
+	return levels
+}
+
+// semanticNonNullType returns the type of definition with its semantically non-null levels made non-null.
+func semanticNonNullType(definition *ast.FieldDefinition) *ast.Type {
+	levels := semanticNonNullLevels(definition)
+	if len(levels) == 0 {
+		return definition.Type
+	}
+
+	return withNonNullLevels(definition.Type, levels, 0)
+}
+
+func withNonNullLevels(typ *ast.Type, levels []int, level int) *ast.Type {
+	if typ == nil {
+		return nil
+	}
+
+	copied := *typ
+	copied.NonNull = typ.NonNull || slices.Contains(levels, level)
+	copied.Elem = withNonNullLevels(typ.Elem, levels, level+1)
+
+	return &copied
+}
+
+// semanticNonNullPaths returns the response paths of the semantically non-null fields of selectionSet,
+// in the format of clientv2.Operation.SemanticNonNull.
+func semanticNonNullPaths(selectionSet ast.SelectionSet) []string {
+	var paths []string
+	collectSemanticNonNullPaths(selectionSet, "", &paths)
+	slices.Sort(paths)
+
+	return slices.Compact(paths)
+}
+
+func collectSemanticNonNullPaths(selectionSet ast.SelectionSet, parent string, paths *[]string) {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Definition == nil {
+				continue
+			}
+
+			path := selection.Alias
+			if parent != "" {
+				path = parent + "." + path
+			}
+
+			for _, level := range semanticNonNullLevels(selection.Definition) {
+				*paths = append(*paths, path+strings.Repeat("[]", level))
+			}
+
+			listPath := path
+			for typ := selection.Definition.Type; typ.Elem != nil; typ = typ.Elem {
+				listPath += "[]"
+			}
+			collectSemanticNonNullPaths(selection.SelectionSet, listPath, paths)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				collectSemanticNonNullPaths(selection.Definition.SelectionSet, parent, paths)
+			}
+		case *ast.InlineFragment:
+			collectSemanticNonNullPaths(selection.SelectionSet, parent, paths)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"go/types"
 
 	"github.com/99designs/gqlgen/codegen/templates"
+	"github.com/Yamashou/gqlgenc/clientv2"
 	"github.com/Yamashou/gqlgenc/config"
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/formatter"
@@ -63,20 +64,95 @@ type Operation struct {
 	Name                string
 	ResponseStructName  string
 	Operation           string
+	DocumentHash        string
+	OperationType       ast.Operation
+	RootFields          []string
+	SourceFile          string
+	SemanticNonNull     []string
+	IsIncremental       bool
 	Args                []*Argument
 	VariableDefinitions ast.VariableDefinitionList
 }
 
 func NewOperation(operation *ast.OperationDefinition, queryDocument *ast.QueryDocument, args []*Argument, generateConfig *config.GenerateConfig) *Operation {
+	document := queryString(queryDocument)
+
+	var semanticNonNull []string
+	if generateConfig.ShouldUseSemanticNonNull() {
+		semanticNonNull = semanticNonNullPaths(operation.SelectionSet)
+	}
+
 	return &Operation{
 		Name:                operation.Name,
 		ResponseStructName:  getResponseStructName(operation, generateConfig),
-		Operation:           queryString(queryDocument),
+		Operation:           document,
+		DocumentHash:        clientv2.DocumentHash(document),
+		OperationType:       operation.Operation,
+		RootFields:          clientv2.RootFields(queryDocument, operation.SelectionSet),
+		SourceFile:          sourceFile(operation),
+		SemanticNonNull:     semanticNonNull,
+		IsIncremental:       isIncremental(queryDocument),
 		Args:                args,
 		VariableDefinitions: operation.VariableDefinitions,
 	}
 }
 
+func (o *Operation) IsSubscription() bool {
+	return o.OperationType == ast.Subscription
+}
+
+// sourceFile returns the name of the query file the operation was parsed from.
+func sourceFile(operation *ast.OperationDefinition) string {
+	if operation.Position == nil || operation.Position.Src == nil {
+		return ""
+	}
+
+	return operation.Position.Src.Name
+}
+
+// isIncremental reports whether the document uses @defer or @stream, so results are delivered incrementally.
+func isIncremental(queryDocument *ast.QueryDocument) bool {
+	if queryDocument == nil {
+		return false
+	}
+
+	for _, operation := range queryDocument.Operations {
+		if hasIncrementalDirective(operation.SelectionSet) {
+			return true
+		}
+	}
+	for _, fragment := range queryDocument.Fragments {
+		if hasIncrementalDirective(fragment.SelectionSet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasIncrementalDirective(selectionSet ast.SelectionSet) bool {
+	for _, selection := range selectionSet {
+		var (
+			directives ast.DirectiveList
+			children   ast.SelectionSet
+		)
+		switch selection := selection.(type) {
+		case *ast.Field:
+			directives, children = selection.Directives, selection.SelectionSet
+		case *ast.InlineFragment:
+			directives, children = selection.Directives, selection.SelectionSet
+		case *ast.FragmentSpread:
+			directives = selection.Directives
+		}
+
+		if directives.ForName("defer") != nil || directives.ForName("stream") != nil || hasIncrementalDirective(children) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func ValidateOperationList(os ast.OperationList) error {
 	if err := IsUniqueName(os); err != nil {
 		return fmt.Errorf("is not unique operation name: %w", err)
@@ -143,6 +219,9 @@ func queryString(queryDocument *ast.QueryDocument) string {
 type OperationResponse struct {
 	Name string
 	Type types.Type
+	// ErrorsMethod is the name of the method returning the GraphQL errors of the response,
+	// Errors unless the response has an Errors field. It is empty unless the responses keep their errors.
+	ErrorsMethod string
 }
 
 func (s *Source) OperationResponses() ([]*OperationResponse, error) {
@@ -153,10 +232,15 @@ func (s *Source) OperationResponses() ([]*OperationResponse, error) {
 		if s.sourceGenerator.cfg.Models.Exists(name) {
 			return nil, fmt.Errorf("%s is duplicated", name)
 		}
-		operationResponse = append(operationResponse, &OperationResponse{
+		response := &OperationResponse{
 			Name: name,
 			Type: responseFields.StructType(),
-		})
+		}
+		if s.generateConfig.ShouldGenerateResponseErrors() {
+			response.Type, response.ErrorsMethod = withResponseErrors(responseFields.StructType())
+		}
+
+		operationResponse = append(operationResponse, response)
 	}
 
 	for _, operationResponse := range operationResponse {
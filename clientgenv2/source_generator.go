@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/types"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/99designs/gqlgen/codegen/config"
@@ -216,13 +217,45 @@ func NewSourceGenerator(cfg *config.Config, client config.PackageConfig, generat
 
 func (r *SourceGenerator) NewResponseFields(selectionSet ast.SelectionSet, typeName string) ResponseFieldList {
 	responseFields := make(ResponseFieldList, 0, len(selectionSet))
+	// unlabeled deferred fragments are told apart by their position among the ones of the selection set
+	var unlabeled int
 	for _, selection := range selectionSet {
+		if deferDirective := selectionDirectives(selection).ForName("defer"); deferDirective != nil {
+			var position int
+			if deferLabel(deferDirective) == "" {
+				unlabeled++
+				position = unlabeled
+			}
+			responseFields = append(responseFields, r.newDeferredResponseField(selection, deferDirective, position, typeName))
+
+			continue
+		}
+
 		responseFields = append(responseFields, r.NewResponseField(selection, typeName))
 	}
 
 	return responseFields
 }
 
+func selectionDirectives(selection ast.Selection) ast.DirectiveList {
+	switch selection := selection.(type) {
+	case *ast.FragmentSpread:
+		return selection.Directives
+	case *ast.InlineFragment:
+		return selection.Directives
+	default:
+		return nil
+	}
+}
+
+func deferLabel(deferDirective *ast.Directive) string {
+	if arg := deferDirective.Arguments.ForName("label"); arg != nil && arg.Value != nil {
+		return arg.Value.Raw
+	}
+
+	return ""
+}
+
 func NewLayerTypeName(base, thisField string) string {
 	return fmt.Sprintf("%s_%s", cases.Title(language.Und, cases.NoLower).String(base), thisField)
 }
@@ -234,7 +267,11 @@ func (r *SourceGenerator) NewResponseField(selection ast.Selection, typeName str
 		typeName = NewLayerTypeName(typeName, templates.ToGo(selection.Alias))
 		fieldsResponseFields := r.NewResponseFields(selection.SelectionSet, typeName)
 
-		isOptional = !selection.Definition.Type.NonNull
+		fieldType := selection.Definition.Type
+		if r.generateConfig.ShouldUseSemanticNonNull() {
+			fieldType = semanticNonNullType(selection.Definition)
+		}
+		isOptional = !fieldType.NonNull
 
 		var baseType types.Type
 		switch {
@@ -271,7 +308,7 @@ func (r *SourceGenerator) NewResponseField(selection ast.Selection, typeName str
 
 		// GraphQLの定義がオプショナルのはtypeのポインタ型が返り、配列の定義場合はポインタのスライスの型になって返ってきます
 		// return pointer type then optional type or slice pointer then slice type of definition in GraphQL.
-		typ := r.binder.CopyModifiersFromAst(selection.Definition.Type, baseType)
+		typ := r.binder.CopyModifiersFromAst(fieldType, baseType)
 
 		// json tag
 		jsonTag := fmt.Sprintf(`json:"%s`, selection.Alias)
@@ -405,6 +442,104 @@ func (r *SourceGenerator) NewResponseField(selection ast.Selection, typeName str
 	panic("unexpected selection type")
 }
 
+// newDeferredResponseField creates the field for a fragment marked with @defer.
+// Unlike other fragments it is not merged into the parent, so that its struct can embed
+// clientv2.DeferredFragment to report whether the deferred data has been delivered.
+// Labeled fragments are named after their label. Unlabeled fragments are named after the fragment they spread,
+// or Deferred followed by their position when it is not the first, and their position is kept in the defer tag.
+func (r *SourceGenerator) newDeferredResponseField(selection ast.Selection, deferDirective *ast.Directive, position int, typeName string) *ResponseField {
+	var name, typeCondition string
+	var selectionSet ast.SelectionSet
+	switch selection := selection.(type) {
+	case *ast.FragmentSpread:
+		name = selection.Name
+		typeCondition = selection.Definition.TypeCondition
+		selectionSet = selection.Definition.SelectionSet
+	case *ast.InlineFragment:
+		name = "Deferred"
+		if position > 1 {
+			name += strconv.Itoa(position)
+		}
+		typeCondition = selection.TypeCondition
+		selectionSet = selection.SelectionSet
+	}
+
+	deferTag := fmt.Sprintf(`defer:",%d"`, position)
+	if label := deferLabel(deferDirective); label != "" {
+		name = label
+		deferTag = fmt.Sprintf(`defer:"%s"`, label)
+	}
+
+	typeName = NewLayerTypeName(typeName, templates.ToGo(name))
+	generator := NewStructGenerator(r.NewResponseFields(selectionSet, typeName))
+	r.StructSources = generator.MergedStructSources(r.StructSources)
+	fields := generator.GetCurrentResponseFieldList()
+
+	vars := []*types.Var{types.NewField(0, nil, "DeferredFragment", deferredFragmentType, true)}
+	tags := []string{""}
+	fieldsStructType := fields.StructType()
+	for i := range fieldsStructType.NumFields() {
+		vars = append(vars, fieldsStructType.Field(i))
+		tags = append(tags, fieldsStructType.Tag(i))
+	}
+
+	structType := types.NewStruct(vars, tags)
+	r.StructSources = append(r.StructSources, &StructSource{
+		Name: typeName,
+		Type: structType,
+	})
+
+	graphqlTag := `graphql:"..."`
+	if typeCondition != "" {
+		graphqlTag = fmt.Sprintf(`graphql:"... on %s"`, typeCondition)
+	}
+
+	return &ResponseField{
+		Name: name,
+		Type: types.NewNamed(
+			types.NewTypeName(0, r.client.Pkg(), typeName, nil),
+			structType,
+			nil,
+		),
+		Tags:           []string{graphqlTag, deferTag},
+		ResponseFields: fields,
+	}
+}
+
+var clientv2Package = types.NewPackage("github.com/Yamashou/gqlgenc/clientv2", "clientv2")
+
+var deferredFragmentType = types.NewNamed(
+	types.NewTypeName(0, clientv2Package, "DeferredFragment", nil),
+	types.NewStruct(nil, nil),
+	nil,
+)
+
+var responseErrorsType = types.NewNamed(
+	types.NewTypeName(0, clientv2Package, "ResponseErrors", nil),
+	types.NewSlice(types.NewPointer(types.NewStruct(nil, nil))),
+	nil,
+)
+
+// withResponseErrors adds to the struct of an operation response the unexported errors field,
+// which the generated client sets to the GraphQL errors of the response, and returns the name of its method.
+func withResponseErrors(structType *types.Struct) (*types.Struct, string) {
+	errorsMethod := "Errors"
+	vars := make([]*types.Var, 0, structType.NumFields()+1)
+	tags := make([]string, 0, structType.NumFields()+1)
+	for i := range structType.NumFields() {
+		if structType.Field(i).Name() == errorsMethod {
+			errorsMethod = "GraphQLErrors"
+		}
+		vars = append(vars, structType.Field(i))
+		tags = append(tags, structType.Tag(i))
+	}
+
+	vars = append(vars, types.NewField(0, nil, "errors", responseErrorsType, false))
+	tags = append(tags, "")
+
+	return types.NewStruct(vars, tags), errorsMethod
+}
+
 func (r *SourceGenerator) OperationArguments(variableDefinitions ast.VariableDefinitionList) []*Argument {
 	argumentTypes := make([]*Argument, 0, len(variableDefinitions))
 	for _, v := range variableDefinitions {
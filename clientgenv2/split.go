@@ -0,0 +1,235 @@
+package clientgenv2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/codegen/config"
+	gqlgencConfig "github.com/Yamashou/gqlgenc/config"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// splitFileSuffix ends the names of the split files, so that they are never taken for tests or build constrained.
+const splitFileSuffix = "_gen.go"
+
+// splitDirective is written below the package line of the split files, followed by the name of the client file,
+// to tell them from the other files when removing stale ones.
+const splitDirective = "//gqlgenc:split "
+
+// RenderSplitTemplates writes the client, the fragments and the types they use to the client file, and the operations
+// with their responses to a file per query file or per operation next to it. The split files of a previous
+// generation that are not written anymore are removed.
+func RenderSplitTemplates(cfg *config.Config, fragments []*Fragment, operations []*Operation, operationResponses []*OperationResponse, structSources []*StructSource, generateCfg *gqlgencConfig.GenerateConfig, client config.PackageConfig) error {
+	files := splitClientFiles(client.Filename, generateCfg.GetSplit(), fragments, operations, operationResponses, structSources)
+
+	filenames := make([]string, 0, len(files))
+	for _, file := range files {
+		if err := renderClientFile(cfg, file, operations, generateCfg, client); err != nil {
+			return err
+		}
+		filenames = append(filenames, file.Filename)
+	}
+
+	return RemoveStaleSplitFiles(client.Filename, filenames)
+}
+
+// splitClientFiles returns the shared client file followed by the split files, in the order of the operations.
+// The types of a response go to the file of its operation, and the other types to the client file.
+func splitClientFiles(clientFilename string, split gqlgencConfig.ClientSplit, fragments []*Fragment, operations []*Operation, operationResponses []*OperationResponse, structSources []*StructSource) []*clientFile {
+	shared := &clientFile{Filename: clientFilename, Shared: true, Fragments: fragments}
+	files := []*clientFile{shared}
+
+	names := splitNames(split, operations)
+	responses := make(map[string]*OperationResponse, len(operationResponses))
+	for _, response := range operationResponses {
+		responses[response.Name] = response
+	}
+
+	filesByName := make(map[string]*clientFile)
+	operationFiles := make(map[*Operation]*clientFile, len(operations))
+	for _, operation := range operations {
+		file := shared
+		if name := names[operation]; name != "" {
+			filename := splitFilename(clientFilename, name)
+			if file = filesByName[filename]; file == nil {
+				file = &clientFile{
+					Filename:   filename,
+					FileNotice: splitDirective + filepath.Base(clientFilename),
+				}
+				filesByName[filename] = file
+				files = append(files, file)
+			}
+		}
+		operationFiles[operation] = file
+
+		file.Operations = append(file.Operations, operation)
+		if response := responses[operation.ResponseStructName]; response != nil {
+			file.OperationResponses = append(file.OperationResponses, response)
+		}
+	}
+
+	for _, structSource := range structSources {
+		file := shared
+		if operation := structSourceOperation(structSource, operations); operation != nil {
+			file = operationFiles[operation]
+		}
+		file.StructSources = append(file.StructSources, structSource)
+	}
+
+	return files
+}
+
+// splitNames returns the names the split files of the operations are named after: their query file relative
+// to the directory of all the query files, or their own name. Operations without a query file stay in the client file.
+func splitNames(split gqlgencConfig.ClientSplit, operations []*Operation) map[*Operation]string {
+	names := make(map[*Operation]string, len(operations))
+	if split == gqlgencConfig.ClientSplitOperation {
+		for _, operation := range operations {
+			names[operation] = operation.Name
+		}
+
+		return names
+	}
+
+	var dir string
+	for _, operation := range operations {
+		if operation.SourceFile == "" {
+			continue
+		}
+
+		sourceDir := filepath.Dir(filepath.Clean(operation.SourceFile))
+		if dir == "" {
+			dir = sourceDir
+		}
+		for !isWithin(sourceDir, dir) && dir != filepath.Dir(dir) {
+			dir = filepath.Dir(dir)
+		}
+	}
+
+	for _, operation := range operations {
+		if operation.SourceFile == "" {
+			continue
+		}
+
+		name, err := filepath.Rel(dir, filepath.Clean(operation.SourceFile))
+		if err != nil {
+			name = filepath.Base(operation.SourceFile)
+		}
+		names[operation] = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	return names
+}
+
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// structSourceOperation returns the operation whose response has the struct, which is named after the operation.
+func structSourceOperation(structSource *StructSource, operations []*Operation) *Operation {
+	var owner *Operation
+	for _, operation := range operations {
+		prefix := cases.Title(language.Und, cases.NoLower).String(operation.Name) + "_"
+		if strings.HasPrefix(structSource.Name, prefix) && (owner == nil || len(operation.Name) > len(owner.Name)) {
+			owner = operation
+		}
+	}
+
+	return owner
+}
+
+// splitFilename returns the name of the split file named after name, such as client_get_user_gen.go for
+// the operation GetUser of client.go.
+func splitFilename(clientFilename, name string) string {
+	base := strings.TrimSuffix(filepath.Base(clientFilename), ".go")
+
+	return filepath.Join(filepath.Dir(clientFilename), base+"_"+snakeCase(name)+splitFileSuffix)
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '_' })
+
+	return strings.Join(parts, "_")
+}
+
+// SplitFiles returns the split files of the client file on disk.
+func SplitFiles(clientFilename string) ([]string, error) {
+	dir := filepath.Dir(clientFilename)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read client directory: %w", err)
+	}
+
+	base := filepath.Base(clientFilename)
+	prefix := strings.TrimSuffix(base, ".go") + "_"
+	directive := []byte("\n" + splitDirective + base + "\n")
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) || !strings.HasSuffix(entry.Name(), splitFileSuffix) {
+			continue
+		}
+
+		filename := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		if bytes.HasPrefix(content, []byte(packageDoc)) && bytes.Contains(content, directive) {
+			files = append(files, filename)
+		}
+	}
+
+	return files, nil
+}
+
+// RemoveStaleSplitFiles removes the split files of the client file on disk but the ones in keep.
+func RemoveStaleSplitFiles(clientFilename string, keep []string) error {
+	files, err := SplitFiles(clientFilename)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, filename := range keep {
+		kept[filepath.Clean(filename)] = true
+	}
+
+	for _, filename := range files {
+		if kept[filepath.Clean(filename)] {
+			continue
+		}
+		if err := os.Remove(filename); err != nil {
+			return fmt.Errorf("remove stale %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package clientgenv2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gqlgencConfig "github.com/Yamashou/gqlgenc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "GetUser", expected: "gen/client_get_user_gen.go"},
+		{name: "getUserByID", expected: "gen/client_get_user_by_id_gen.go"},
+		{name: "users", expected: "gen/client_users_gen.go"},
+		{name: filepath.Join("admin", "list-users"), expected: "gen/client_admin_list_users_gen.go"},
+		{name: "windows", expected: "gen/client_windows_gen.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, filepath.FromSlash(tt.expected), splitFilename(filepath.Join("gen", "client.go"), tt.name))
+		})
+	}
+}
+
+func TestSplitClientFiles(t *testing.T) {
+	t.Parallel()
+
+	getUser := &Operation{Name: "GetUser", ResponseStructName: "GetUser", SourceFile: "queries/users.graphql"}
+	updateUser := &Operation{Name: "UpdateUser", ResponseStructName: "UpdateUserPayload", SourceFile: "queries/users.graphql"}
+	listRepositories := &Operation{Name: "listRepositories", ResponseStructName: "listRepositories", SourceFile: "queries/repositories/list.graphql"}
+	operations := []*Operation{getUser, updateUser, listRepositories}
+	responses := []*OperationResponse{{Name: "GetUser"}, {Name: "UpdateUserPayload"}, {Name: "listRepositories"}}
+	structSources := []*StructSource{{Name: "GetUser_User"}, {Name: "ListRepositories_Repositories"}, {Name: "UserFields_Followers"}}
+	fragments := []*Fragment{{Name: "UserFields"}}
+
+	files := splitClientFiles("client.go", gqlgencConfig.ClientSplitQueryFile, fragments, operations, responses, structSources)
+	require.Len(t, files, 3)
+
+	require.Equal(t, "client.go", files[0].Filename)
+	require.True(t, files[0].Shared)
+	require.Equal(t, fragments, files[0].Fragments)
+	require.Empty(t, files[0].Operations)
+	require.Equal(t, []*StructSource{structSources[2]}, files[0].StructSources)
+
+	require.Equal(t, "client_users_gen.go", files[1].Filename)
+	require.Equal(t, "//gqlgenc:split client.go", files[1].FileNotice)
+	require.Equal(t, []*Operation{getUser, updateUser}, files[1].Operations)
+	require.Equal(t, responses[:2], files[1].OperationResponses)
+	require.Equal(t, []*StructSource{structSources[0]}, files[1].StructSources)
+
+	require.Equal(t, filepath.FromSlash("client_repositories_list_gen.go"), files[2].Filename)
+	require.Equal(t, []*Operation{listRepositories}, files[2].Operations)
+	require.Equal(t, []*StructSource{structSources[1]}, files[2].StructSources)
+
+	files = splitClientFiles("client.go", gqlgencConfig.ClientSplitOperation, fragments, operations, responses, structSources)
+	require.Len(t, files, 4)
+	require.Equal(t, "client_update_user_gen.go", files[2].Filename)
+	require.Equal(t, []*Operation{updateUser}, files[2].Operations)
+}
+
+func TestRemoveStaleSplitFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	clientFilename := filepath.Join(dir, "client.go")
+	write := func(name, content string) string {
+		filename := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+
+		return filename
+	}
+
+	kept := write("client_users_gen.go", packageDoc+"\npackage generated\n\n//gqlgenc:split client.go\n")
+	write("client_old_gen.go", packageDoc+"\npackage generated\n\n//gqlgenc:split client.go\n")
+	write("client_mock_gen.go", packageDoc+"\npackage generated\n")
+	write("client_handwritten_gen.go", "package generated\n\n//gqlgenc:split client.go\n")
+	write("other_users_gen.go", packageDoc+"\npackage generated\n\n//gqlgenc:split other.go\n")
+
+	files, err := SplitFiles(clientFilename)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{kept, filepath.Join(dir, "client_old_gen.go")}, files)
+
+	require.NoError(t, RemoveStaleSplitFiles(clientFilename, []string{kept}))
+	files, err = SplitFiles(clientFilename)
+	require.NoError(t, err)
+	require.Equal(t, []string{kept}, files)
+	require.FileExists(t, filepath.Join(dir, "client_mock_gen.go"))
+	require.FileExists(t, filepath.Join(dir, "client_handwritten_gen.go"))
+}
@@ -15,29 +15,67 @@ import (
 //go:embed template.gotpl
 var template string
 
+//go:embed mock.gotpl
+var mockTemplate string
+
+// packageDoc is written above the package line of the generated files.
+const packageDoc = "// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.\n"
+
 func RenderTemplate(cfg *config.Config, fragments []*Fragment, operations []*Operation, operationResponses []*OperationResponse, structSources []*StructSource, generateCfg *gqlgencConfig.GenerateConfig, client config.PackageConfig) error {
+	file := &clientFile{
+		Filename:           client.Filename,
+		Shared:             true,
+		Fragments:          fragments,
+		Operations:         operations,
+		OperationResponses: operationResponses,
+		StructSources:      structSources,
+	}
+
+	return renderClientFile(cfg, file, operations, generateCfg, client)
+}
+
+// clientFile is a file of the generated client package.
+type clientFile struct {
+	Filename string
+	// Shared is true for the file of the client, its interface and DocumentOperationNames.
+	Shared bool
+	// FileNotice is written below the package line.
+	FileNotice         string
+	Fragments          []*Fragment
+	Operations         []*Operation
+	OperationResponses []*OperationResponse
+	StructSources      []*StructSource
+}
+
+// renderClientFile writes file, where clientOperations are all the operations of the client.
+func renderClientFile(cfg *config.Config, file *clientFile, clientOperations []*Operation, generateCfg *gqlgencConfig.GenerateConfig, client config.PackageConfig) error {
 	genGettersGenerator := &GenGettersGenerator{
 		ClientPackageName: client.Package,
 	}
 	if err := templates.Render(templates.Options{
 		PackageName: client.Package,
-		Filename:    client.Filename,
+		Filename:    file.Filename,
 		Template:    template,
 		Data: map[string]any{
-			"Fragment":            fragments,
-			"Operation":           operations,
-			"OperationResponse":   operationResponses,
+			"Shared":              file.Shared,
+			"Fragment":            file.Fragments,
+			"Operation":           file.Operations,
+			"ClientOperation":     clientOperations,
+			"OperationResponse":   file.OperationResponses,
 			"GenerateClient":      generateCfg.ShouldGenerateClient(),
-			"StructSources":       structSources,
+			"ResponseErrors":      generateCfg.ShouldGenerateResponseErrors(),
+			"StructSources":       file.StructSources,
 			"ClientInterfaceName": generateCfg.GetClientInterfaceName(),
 		},
 		Packages:   cfg.Packages,
-		PackageDoc: "// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.\n",
+		PackageDoc: packageDoc,
+		FileNotice: file.FileNotice,
 		Funcs: map[string]any{
 			"genGetters": genGettersGenerator.GenFunc(),
+			"argName":    argName,
 		},
 	}); err != nil {
-		return fmt.Errorf("%s generating failed: %w", client.Filename, err)
+		return fmt.Errorf("%s generating failed: %w", file.Filename, err)
 	}
 
 	return nil
@@ -47,6 +85,58 @@ type GenGettersGenerator struct {
 	ClientPackageName string
 }
 
+// RenderMockTemplate writes a mock of the client interface to the file of mockConfig, in the package of the client.
+func RenderMockTemplate(cfg *config.Config, operations []*Operation, generateCfg *gqlgencConfig.GenerateConfig, mockConfig *gqlgencConfig.MockConfig, client config.PackageConfig) error {
+	if err := templates.Render(templates.Options{
+		PackageName: client.Package,
+		Filename:    mockConfig.Filename,
+		Template:    mockTemplate,
+		Data: map[string]any{
+			"Operation":           operations,
+			"ClientInterfaceName": *generateCfg.GetClientInterfaceName(),
+		},
+		Packages:   cfg.Packages,
+		PackageDoc: packageDoc,
+		Funcs: map[string]any{
+			"argName":       argName,
+			"callFieldName": callFieldName,
+		},
+	}); err != nil {
+		return fmt.Errorf("%s generating failed: %w", mockConfig.Filename, err)
+	}
+
+	return nil
+}
+
+// reservedArgNames are the receivers, parameters and variables of the generated methods, which the parameters of the
+// operation variables must not redeclare, and the packages they use, which the parameters must not shadow.
+var reservedArgNames = map[string]bool{
+	"c": true, "m": true, "ctx": true, "interceptors": true, "vars": true, "res": true,
+	"clientv2": true, "errors": true, "io": true, "iter": true,
+}
+
+// argName returns the parameter of an operation variable, followed by _ when its name is reserved.
+// templates.ToGoPrivate never ends a name with _, so it cannot be the parameter of another variable.
+func argName(variable string) string {
+	name := templates.ToGoPrivate(variable)
+	if reservedArgNames[name] {
+		return name + "_"
+	}
+
+	return name
+}
+
+// callFieldName returns the field of an operation variable in the call structs of the mock,
+// followed by _ when it is Ctx or Interceptors, the fields of the other arguments of the call.
+func callFieldName(variable string) string {
+	name := templates.ToGo(variable)
+	if name == "Ctx" || name == "Interceptors" {
+		return name + "_"
+	}
+
+	return name
+}
+
 func (g *GenGettersGenerator) GenFunc() func(name string, p types.Type) string {
 	// This method returns a string of getters for a struct.
 	// The idea is to be able to chain calls safely without having to check for nil.
@@ -61,6 +151,9 @@ func (g *GenGettersGenerator) GenFunc() func(name string, p types.Type) string {
 
 		for i := range it.NumFields() {
 			field := it.Field(i)
+			if field.Embedded() || !field.Exported() {
+				continue
+			}
 
 			returns := g.returnTypeName(field.Type(), false)
 
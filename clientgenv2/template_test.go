@@ -64,3 +64,30 @@ func TestReturnTypeName(t *testing.T) {
 		})
 	}
 }
+
+func TestArgName(t *testing.T) {
+	tests := []struct {
+		variable  string
+		argName   string
+		callField string
+	}{
+		{variable: "room", argName: "room", callField: "Room"},
+		{variable: "ctx", argName: "ctx_", callField: "Ctx_"},
+		{variable: "interceptors", argName: "interceptors_", callField: "Interceptors_"},
+		{variable: "vars", argName: "vars_", callField: "Vars"},
+		{variable: "errors", argName: "errors_", callField: "Errors"},
+		{variable: "io", argName: "io_", callField: "Io"},
+		{variable: "type", argName: "typeArg", callField: "Type"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.variable, func(t *testing.T) {
+			if got := argName(test.variable); got != test.argName {
+				t.Errorf("argName(%q) = %q, want %q", test.variable, got, test.argName)
+			}
+			if got := callFieldName(test.variable); got != test.callField {
+				t.Errorf("callFieldName(%q) = %q, want %q", test.variable, got, test.callField)
+			}
+		})
+	}
+}
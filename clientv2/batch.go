@@ -0,0 +1,328 @@
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// errBatchWithCustomDo is returned when operations would be batched by a client with CustomDo,
+// which sends a single operation per request.
+var errBatchWithCustomDo = errors.New("operations cannot be batched when CustomDo is set")
+
+// BatchRequest is an operation sent by PostBatch.
+type BatchRequest struct {
+	OperationName string
+	Query         string
+	Variables     map[string]any
+	// Operation describes the operation, it is described from Query when nil.
+	Operation *Operation
+	// RespData is the response struct the result of the operation is decoded into.
+	RespData any
+	// Err is set by PostBatch to the error of the operation, in the same form Post returns it.
+	Err error
+}
+
+// PostBatch sends the operations in one request as a JSON array, the batching convention of Apollo Server,
+// and decodes each result of the response array into the RespData of its operation.
+// The returned error reports a failure of the whole batch, the error of each operation is set in its Err.
+// Files cannot be uploaded in a batch, and PostBatch fails when CustomDo is set.
+func (c *Client) PostBatch(ctx context.Context, requests []*BatchRequest, interceptors ...RequestInterceptor) error {
+	if c.CustomDo != nil {
+		return errBatchWithCustomDo
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	batch := make([]*Request, 0, len(requests))
+	for _, r := range requests {
+		if hasUploads(r.Variables) {
+			return fmt.Errorf("operation %s uploads files, which cannot be sent in a batch", r.OperationName)
+		}
+
+		operation := r.Operation
+		if operation == nil {
+			operation = c.describeOperation(r.OperationName, r.Query)
+		}
+
+		batch = append(batch, &Request{
+			Query:         r.Query,
+			Variables:     r.Variables,
+			OperationName: r.OperationName,
+			Operation:     operation,
+		})
+	}
+
+	body, err := MarshalJSON(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request struct failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+
+	gqlInfo := &GQLRequestInfo{Request: batch[0], Operation: batch[0].Operation, Batch: batch}
+
+	return c.chainInterceptors(interceptors)(ctx, req, gqlInfo, requests, c.doBatch)
+}
+
+func (c *Client) doBatch(_ context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any) error {
+	requests, ok := res.([]*BatchRequest)
+	if !ok {
+		return fmt.Errorf("batch response must be []*BatchRequest, got %T", res)
+	}
+
+	body, resp, err := c.roundTrip(req)
+	if err != nil {
+		return err
+	}
+	httpCode := resp.StatusCode
+	gqlInfo.ResponseBody = body
+	gqlInfo.ResponseStatusCode = httpCode
+
+	var results []json.RawMessage
+	if err := json.Unmarshal(body, &results); err != nil {
+		// servers without batching support answer with a single error response
+		errResponse := &ErrorResponse{}
+		if httpCode < 200 || 299 < httpCode {
+			errResponse.NetworkError = &HTTPError{
+				Code:    httpCode,
+				Message: fmt.Sprintf("Response body %s", string(body)),
+				Header:  resp.Header,
+			}
+		}
+
+		var gqlErrs GqlErrorList
+		if json.Unmarshal(body, &gqlErrs) == nil && len(gqlErrs.Errors) > 0 {
+			errResponse.GqlErrors = &gqlErrs.Errors
+		}
+
+		if errResponse.HasErrors() {
+			return errResponse
+		}
+
+		return fmt.Errorf("failed to decode batch response %s: %w", string(body), &DecodeError{Body: body, Err: err})
+	}
+
+	if len(results) != len(requests) {
+		return fmt.Errorf("batch response has %d results for %d operations", len(results), len(requests))
+	}
+
+	for i, r := range requests {
+		r.Err = checkSemanticNonNull(r.Operation, results[i], c.parseResponse(results[i], httpCode, r.RespData))
+	}
+
+	return nil
+}
+
+type batchKey struct{}
+
+// WithBatchKey sets the batch key of the requests made with the returned context. Automatic batching only sends
+// requests with the same batch key together, and the interceptors of a batch get the values of the context of its
+// first request. Requests whose contexts carry differing credentials or tenants must be given a batch key per
+// credential or tenant, so that they are never sent with the credentials of another request.
+func WithBatchKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, batchKey{}, key)
+}
+
+// batcher coalesces the Post calls made within a time window into one PostBatch call per batch key.
+type batcher struct {
+	client  *Client
+	window  time.Duration
+	maxSize int
+
+	mu sync.Mutex
+	// pending holds the batch being filled of each batch key.
+	pending map[string]*pendingBatch
+}
+
+type pendingBatch struct {
+	requests []*pendingRequest
+	timer    *time.Timer
+}
+
+type pendingRequest struct {
+	ctx     context.Context
+	request *BatchRequest
+	done    chan struct{}
+}
+
+func newBatcher(c *Client, window time.Duration, maxSize int) *batcher {
+	return &batcher{
+		client:  c,
+		window:  window,
+		maxSize: maxSize,
+		pending: make(map[string]*pendingBatch),
+	}
+}
+
+// do adds r to the current batch of its batch key and waits for its result.
+// The batch decodes the response into a value of its own, copied to respData once the batch is done, so that
+// respData is left untouched when ctx is done first.
+func (b *batcher) do(ctx context.Context, r *Request, respData any) error {
+	if b.client.CustomDo != nil {
+		return errBatchWithCustomDo
+	}
+
+	buffer, private := newResponseBuffer(respData)
+	p := &pendingRequest{
+		ctx: ctx,
+		request: &BatchRequest{
+			OperationName: r.OperationName,
+			Query:         r.Query,
+			Variables:     r.Variables,
+			Operation:     r.Operation,
+			RespData:      buffer,
+		},
+		done: make(chan struct{}),
+	}
+
+	key, _ := ctx.Value(batchKey{}).(string)
+
+	b.mu.Lock()
+	batch := b.pending[key]
+	if batch == nil {
+		batch = &pendingBatch{}
+		b.pending[key] = batch
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			requests := b.take(key, batch)
+			b.mu.Unlock()
+
+			b.send(requests)
+		})
+	}
+	batch.requests = append(batch.requests, p)
+	if b.maxSize > 0 && len(batch.requests) >= b.maxSize {
+		batch.timer.Stop()
+		go b.send(b.take(key, batch))
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-p.done:
+		if private {
+			reflect.ValueOf(respData).Elem().Set(reflect.ValueOf(buffer).Elem())
+		}
+
+		return p.request.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newResponseBuffer returns a new value of the type respData points to, and whether it is one.
+// respData itself is returned when it is not a pointer.
+func newResponseBuffer(respData any) (any, bool) {
+	v := reflect.ValueOf(respData)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return respData, false
+	}
+
+	return reflect.New(v.Type().Elem()).Interface(), true
+}
+
+// take returns the requests of batch and starts a new batch for key. It returns nothing when batch has already been
+// taken, by the timer and the max size at once. b.mu must be held.
+func (b *batcher) take(key string, batch *pendingBatch) []*pendingRequest {
+	if b.pending[key] != batch {
+		return nil
+	}
+	delete(b.pending, key)
+
+	return batch.requests
+}
+
+func (b *batcher) send(batch []*pendingRequest) {
+	if len(batch) == 0 {
+		return
+	}
+
+	defer func() {
+		for _, p := range batch {
+			close(p.done)
+		}
+	}()
+
+	// a batch of one operation is sent as a plain request
+	if len(batch) == 1 {
+		p := batch[0]
+		p.request.Err = b.client.post(p.ctx, &Request{
+			Query:         p.request.Query,
+			Variables:     p.request.Variables,
+			OperationName: p.request.OperationName,
+			Operation:     p.request.Operation,
+		}, p.request.RespData, nil)
+
+		return
+	}
+
+	ctx, cancel := batchContext(batch)
+	defer cancel()
+
+	requests := make([]*BatchRequest, 0, len(batch))
+	for _, p := range batch {
+		requests = append(requests, p.request)
+	}
+
+	if err := b.client.PostBatch(ctx, requests); err != nil {
+		for _, r := range requests {
+			r.Err = err
+		}
+	}
+}
+
+// batchContext returns the context of a batch. Its requests have the same batch key, so the values of the first
+// context stand for all of them. It is done once the contexts of all the requests are, and at the latest of their
+// deadlines, so that the batch goes on as long as a caller waits for it.
+func batchContext(batch []*pendingRequest) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(batch[0].ctx))
+
+	var latest time.Time
+	for _, p := range batch {
+		deadline, ok := p.ctx.Deadline()
+		if !ok {
+			latest = time.Time{}
+
+			break
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	cancelDeadline := context.CancelFunc(func() {})
+	if !latest.IsZero() {
+		ctx, cancelDeadline = context.WithDeadline(ctx, latest)
+	}
+
+	var waiting atomic.Int32
+	waiting.Store(int32(len(batch)))
+	stops := make([]func() bool, 0, len(batch))
+	for _, p := range batch {
+		stops = append(stops, context.AfterFunc(p.ctx, func() {
+			if waiting.Add(-1) == 0 {
+				cancel()
+			}
+		}))
+	}
+
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancelDeadline()
+		cancel()
+	}
+}
@@ -0,0 +1,299 @@
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// serveBatch runs each operation of a batch on the gqlgen test server, and serves the requests that are not a batch
+// as they are.
+func serveBatch(t *testing.T) http.HandlerFunc {
+	h := newGraphQLHandler(transport.POST{})
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			h.ServeHTTP(w, r)
+
+			return
+		}
+
+		results := make([]json.RawMessage, 0, len(batch))
+		for _, operation := range batch {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(operation))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			results = append(results, rec.Body.Bytes())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(results))
+	}
+}
+
+// batchSizes returns the number of operations of each request, zero meaning a request that was not a batch.
+func batchSizes(requests []recordedRequest) []int {
+	sizes := make([]int, 0, len(requests))
+	for _, req := range requests {
+		var batch []json.RawMessage
+		_ = json.Unmarshal(req.body, &batch)
+		sizes = append(sizes, len(batch))
+	}
+
+	return sizes
+}
+
+// blockingTransport sends the requests once release is closed.
+type blockingTransport struct {
+	release chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-t.release
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestPostBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits the results of the batch", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		var name nameRes
+		var setName nameRes
+		requests := []*BatchRequest{
+			{OperationName: "Name", Query: "query Name { name }", RespData: &name},
+			{OperationName: "SetName", Query: "mutation SetName { name }", RespData: &setName},
+		}
+		require.NoError(t, c.PostBatch(context.Background(), requests))
+		require.Equal(t, []int{2}, batchSizes(srv.requests()))
+
+		require.NoError(t, requests[0].Err)
+		require.Equal(t, "test", name.Name)
+
+		var errResponse *ErrorResponse
+		require.True(t, errors.As(requests[1].Err, &errResponse))
+		require.Equal(t, "mutations are not supported", (*errResponse.GqlErrors)[0].Message)
+	})
+
+	t.Run("server without batching support", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"json request body could not be decoded"}]}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		err := c.PostBatch(context.Background(), []*BatchRequest{
+			{OperationName: "Name", Query: "query Name { name }", RespData: &nameRes{}},
+		})
+
+		var errResponse *ErrorResponse
+		require.True(t, errors.As(err, &errResponse))
+		require.Equal(t, http.StatusBadRequest, errResponse.NetworkError.Code)
+		require.Equal(t, "json request body could not be decoded", (*errResponse.GqlErrors)[0].Message)
+	})
+
+	t.Run("CustomDo cannot batch", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient(http.DefaultClient, "http://127.0.0.1:0", nil)
+		c.CustomDo = func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any) error {
+			return nil
+		}
+
+		err := c.PostBatch(context.Background(), []*BatchRequest{
+			{OperationName: "Name", Query: "query Name { name }", RespData: &nameRes{}},
+		})
+		require.ErrorIs(t, err, errBatchWithCustomDo)
+	})
+}
+
+func TestPost_batchWindow(t *testing.T) {
+	t.Parallel()
+
+	post := func(c *Client, n int) []error {
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i := range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var res nameRes
+				errs[i] = c.Post(context.Background(), "Name", "query Name { name }", &res, nil)
+				if errs[i] == nil && res.Name != "test" {
+					errs[i] = errors.New("unexpected response")
+				}
+			}()
+		}
+		wg.Wait()
+
+		return errs
+	}
+
+	t.Run("concurrent calls are sent in one batch", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{BatchWindow: 50 * time.Millisecond})
+
+		for _, err := range post(c, 3) {
+			require.NoError(t, err)
+		}
+		require.Equal(t, []int{3}, batchSizes(srv.requests()))
+	})
+
+	t.Run("batch is sent when it reaches the max size", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{BatchWindow: time.Hour, MaxBatchSize: 2})
+
+		for _, err := range post(c, 2) {
+			require.NoError(t, err)
+		}
+		require.Equal(t, []int{2}, batchSizes(srv.requests()))
+	})
+
+	t.Run("calls with different batch keys are sent apart", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{BatchWindow: 50 * time.Millisecond})
+
+		keys := []string{"tenant-a", "tenant-a", "tenant-b"}
+		errs := make(chan error, len(keys))
+		for _, key := range keys {
+			go func() {
+				errs <- c.Post(WithBatchKey(context.Background(), key), "Name", "query Name { name }", &nameRes{}, nil)
+			}()
+		}
+		for range keys {
+			require.NoError(t, <-errs)
+		}
+
+		require.ElementsMatch(t, []int{2, 0}, batchSizes(srv.requests()))
+	})
+
+	t.Run("a cancelled call leaves its response untouched", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		release := make(chan struct{})
+		c := NewClient(&http.Client{Transport: &blockingTransport{release: release}}, srv.URL, &Options{BatchWindow: time.Hour, MaxBatchSize: 2})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var cancelled nameRes
+		cancelledErr := make(chan error)
+		go func() {
+			cancelledErr <- c.Post(ctx, "Name", "query Name { name }", &cancelled, nil)
+		}()
+
+		var res nameRes
+		errs := make(chan error)
+		go func() {
+			errs <- c.Post(context.Background(), "Name", "query Name { name }", &res, nil)
+		}()
+
+		cancel()
+		require.ErrorIs(t, <-cancelledErr, context.Canceled)
+		close(release)
+
+		require.NoError(t, <-errs)
+		require.Equal(t, "test", res.Name)
+		require.Empty(t, cancelled.Name)
+	})
+
+	t.Run("CustomDo cannot batch", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient(http.DefaultClient, "http://127.0.0.1:0", &Options{BatchWindow: time.Millisecond})
+		c.CustomDo = func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any) error {
+			return nil
+		}
+
+		require.ErrorIs(t, c.Post(context.Background(), "Name", "query Name { name }", &nameRes{}, nil), errBatchWithCustomDo)
+	})
+
+	t.Run("a single call is not sent as a batch", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, serveBatch(t))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{BatchWindow: time.Millisecond})
+
+		for _, err := range post(c, 1) {
+			require.NoError(t, err)
+		}
+		require.Equal(t, []int{0}, batchSizes(srv.requests()))
+	})
+}
+
+func TestBatchContext(t *testing.T) {
+	t.Parallel()
+
+	pending := func(contexts ...context.Context) []*pendingRequest {
+		batch := make([]*pendingRequest, 0, len(contexts))
+		for _, ctx := range contexts {
+			batch = append(batch, &pendingRequest{ctx: ctx})
+		}
+
+		return batch
+	}
+
+	t.Run("bounded by the latest deadline", func(t *testing.T) {
+		t.Parallel()
+		first, cancelFirst := context.WithTimeout(WithBatchKey(context.Background(), "tenant-a"), time.Hour)
+		t.Cleanup(cancelFirst)
+		last, cancelLast := context.WithTimeout(context.Background(), 2*time.Hour)
+		t.Cleanup(cancelLast)
+
+		ctx, cancel := batchContext(pending(first, last))
+		t.Cleanup(cancel)
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		lastDeadline, _ := last.Deadline()
+		require.Equal(t, lastDeadline, deadline)
+		require.Equal(t, "tenant-a", ctx.Value(batchKey{}))
+	})
+
+	t.Run("unbounded when a call has no deadline", func(t *testing.T) {
+		t.Parallel()
+		first, cancelFirst := context.WithTimeout(context.Background(), time.Hour)
+		t.Cleanup(cancelFirst)
+
+		ctx, cancel := batchContext(pending(first, context.Background()))
+		t.Cleanup(cancel)
+
+		_, ok := ctx.Deadline()
+		require.False(t, ok)
+	})
+
+	t.Run("done once every call is", func(t *testing.T) {
+		t.Parallel()
+		first, cancelFirst := context.WithCancel(context.Background())
+		last, cancelLast := context.WithCancel(context.Background())
+
+		ctx, cancel := batchContext(pending(first, last))
+		t.Cleanup(cancel)
+
+		cancelFirst()
+		require.Never(t, func() bool { return ctx.Err() != nil }, 50*time.Millisecond, 10*time.Millisecond)
+
+		cancelLast()
+		require.Eventually(t, func() bool { return errors.Is(ctx.Err(), context.Canceled) }, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,606 @@
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Yamashou/gqlgenc/graphqljson"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// CachePolicy selects how query operations use a Cache.
+type CachePolicy int
+
+const (
+	// CachePolicyCacheFirst answers from the cache when it holds every field of the query, and sends the query otherwise.
+	CachePolicyCacheFirst CachePolicy = iota
+	// CachePolicyNetworkOnly always sends the query, and stores its response in the cache.
+	CachePolicyNetworkOnly
+	// CachePolicyCacheAndNetwork answers from the cache when it holds every field of the query, and sends the query
+	// in the background to refresh the cache for the next calls. The query is sent as usual on a cache miss.
+	CachePolicyCacheAndNetwork
+)
+
+type cachePolicyKey struct{}
+
+// WithCachePolicy overrides the policy of the cache interceptor for the requests made with the returned context.
+func WithCachePolicy(ctx context.Context, policy CachePolicy) context.Context {
+	return context.WithValue(ctx, cachePolicyKey{}, policy)
+}
+
+const (
+	rootQueryKey    = "ROOT_QUERY"
+	rootMutationKey = "ROOT_MUTATION"
+)
+
+// cacheRecord holds the fields of an object, keyed by their name and arguments.
+// Values are json.RawMessage for leaf values, cacheReference for entities, cacheRecord for other objects and []any for lists.
+type cacheRecord map[string]any
+
+// cacheReference is the key of an entity in the store.
+type cacheReference string
+
+// Cache is a normalized store of responses: objects with a __typename and an id are stored once as entities,
+// so that every query selecting them sees the latest fields, including the ones returned by mutations.
+// Objects without an id are stored within their parent.
+type Cache struct {
+	mu       sync.RWMutex
+	entities map[string]cacheRecord
+	// possibleTypes holds the types known to match the type conditions of fragments, such as interfaces they implement.
+	possibleTypes map[string]map[string]bool
+	// documents holds the parsed documents of the operations read and written.
+	documents *lru.Cache[string, *ast.QueryDocument]
+}
+
+// documentCacheSize bounds the number of parsed documents a Cache keeps.
+const documentCacheSize = 256
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	// lru.New only fails for a non-positive size
+	documents, _ := lru.New[string, *ast.QueryDocument](documentCacheSize)
+
+	return &Cache{
+		entities:      make(map[string]cacheRecord),
+		possibleTypes: make(map[string]map[string]bool),
+		documents:     documents,
+	}
+}
+
+// NewCacheInterceptor returns an interceptor that answers queries from cache according to policy,
+// and normalizes the responses of queries and mutations into cache.
+// Requests sent by PostBatch and by CustomDo bypass the cache.
+func NewCacheInterceptor(cache *Cache, policy CachePolicy) RequestInterceptor {
+	return func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+		operation := gqlInfo.Operation
+		if gqlInfo.Batch != nil || !(operation.IsQuery() || operation.IsMutation()) {
+			return next(ctx, req, gqlInfo, res)
+		}
+
+		vars := gqlInfo.Request.Variables
+
+		if p, ok := ctx.Value(cachePolicyKey{}).(CachePolicy); ok {
+			policy = p
+		}
+
+		if operation.IsQuery() && policy != CachePolicyNetworkOnly {
+			if data, ok := cache.Read(operation, vars); ok && graphqljson.UnmarshalData(data, res) == nil {
+				if policy == CachePolicyCacheAndNetwork {
+					go cache.refresh(context.WithoutCancel(ctx), req, gqlInfo, next)
+				}
+
+				return nil
+			}
+		}
+
+		if err := next(ctx, req, gqlInfo, res); err != nil {
+			return err
+		}
+
+		cache.writeResponse(operation, vars, gqlInfo.ResponseBody)
+
+		return nil
+	}
+}
+
+// refresh sends the query of a cache hit and stores its response.
+func (c *Cache) refresh(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, next RequestInterceptorFunc) {
+	info := *gqlInfo
+	info.ResponseBody = nil
+	info.ResponseStatusCode = 0
+
+	// the response is only decoded into the cache, so the error of decoding it into an empty struct is ignored
+	var res struct{}
+	_ = next(ctx, req.Clone(ctx), &info, &res)
+
+	c.writeResponse(info.Operation, info.Request.Variables, info.ResponseBody)
+}
+
+func (c *Cache) writeResponse(operation *Operation, vars map[string]any, body []byte) {
+	var resp response
+	if json.Unmarshal(body, &resp) != nil || len(resp.Errors) > 0 {
+		return
+	}
+
+	_ = c.Write(operation, vars, resp.Data)
+}
+
+// Write normalizes data, the data of a response to operation, into the cache.
+func (c *Cache) Write(operation *Operation, vars map[string]any, data json.RawMessage) error {
+	doc, definition, err := c.parseOperation(operation)
+	if err != nil {
+		return err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := cacheWalker{cache: c, doc: doc, vars: vars}
+
+	return w.write(object, definition.SelectionSet, c.entity(rootKey(definition.Operation)))
+}
+
+// Read returns the data of a response to operation built from the cache, or false when the cache misses a field of it.
+func (c *Cache) Read(operation *Operation, vars map[string]any) (json.RawMessage, bool) {
+	doc, definition, err := c.parseOperation(operation)
+	if err != nil {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	root, ok := c.entities[rootKey(definition.Operation)]
+	if !ok {
+		return nil, false
+	}
+
+	w := cacheWalker{cache: c, doc: doc, vars: vars}
+	data := make(map[string]any)
+	if !w.read(root, definition.SelectionSet, data) {
+		return nil, false
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, false
+	}
+
+	return content, true
+}
+
+// Evict removes the entity identified by typename and id, so the queries selecting it are sent again.
+func (c *Cache) Evict(typename, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entities, typename+":"+id)
+}
+
+// Reset removes every entity and query result from the cache.
+func (c *Cache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entities = make(map[string]cacheRecord)
+}
+
+// entity returns the record of key, creating it when needed. c.mu must be held.
+func (c *Cache) entity(key string) cacheRecord {
+	record, ok := c.entities[key]
+	if !ok {
+		record = make(cacheRecord)
+		c.entities[key] = record
+	}
+
+	return record
+}
+
+func rootKey(operation ast.Operation) string {
+	if operation == ast.Mutation {
+		return rootMutationKey
+	}
+
+	return rootQueryKey
+}
+
+func (c *Cache) parseOperation(operation *Operation) (*ast.QueryDocument, *ast.OperationDefinition, error) {
+	queryDocument, ok := c.documents.Get(operation.Document)
+	if !ok {
+		parsed, err := parser.ParseQuery(&ast.Source{Input: operation.Document})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse document: %w", err)
+		}
+		queryDocument = parsed
+		c.documents.Add(operation.Document, queryDocument)
+	}
+
+	definition := queryDocument.Operations.ForName(operation.Name)
+	if definition == nil && len(queryDocument.Operations) == 1 {
+		definition = queryDocument.Operations[0]
+	}
+	if definition == nil {
+		return nil, nil, fmt.Errorf("operation %s not found in document", operation.Name)
+	}
+
+	return queryDocument, definition, nil
+}
+
+// cacheWalker walks the selection sets of an operation along with its data or the cache.
+type cacheWalker struct {
+	cache *Cache
+	doc   *ast.QueryDocument
+	vars  map[string]any
+}
+
+func (w *cacheWalker) write(object map[string]json.RawMessage, selectionSet ast.SelectionSet, record cacheRecord) error {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !w.included(selection.Directives) {
+				continue
+			}
+
+			// fields of fragments that do not apply to the object are missing
+			raw, ok := object[responseKey(selection)]
+			if !ok {
+				continue
+			}
+
+			value, err := w.normalize(raw, selection.SelectionSet)
+			if err != nil {
+				return fmt.Errorf("%s: %w", selection.Name, err)
+			}
+			record[w.fieldKey(selection)] = value
+		case *ast.InlineFragment:
+			if !w.included(selection.Directives) {
+				continue
+			}
+
+			if err := w.writeFragment(object, selection.TypeCondition, selection.SelectionSet, record); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			fragment := w.doc.Fragments.ForName(selection.Name)
+			if fragment == nil || !w.included(selection.Directives) {
+				continue
+			}
+
+			if err := w.writeFragment(object, fragment.TypeCondition, fragment.SelectionSet, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeFragment writes the fields of a fragment. Without the schema, a fragment on another type than the one
+// of the object is known to apply to it when the object has all the fields of the fragment.
+func (w *cacheWalker) writeFragment(object map[string]json.RawMessage, typeCondition string, selectionSet ast.SelectionSet, record cacheRecord) error {
+	typename := objectTypename(object)
+	if typeCondition != "" && typename != "" && typeCondition != typename && w.hasAllFields(object, selectionSet) {
+		possibleTypes, ok := w.cache.possibleTypes[typeCondition]
+		if !ok {
+			possibleTypes = make(map[string]bool)
+			w.cache.possibleTypes[typeCondition] = possibleTypes
+		}
+		possibleTypes[typename] = true
+	}
+
+	return w.write(object, selectionSet, record)
+}
+
+func (w *cacheWalker) hasAllFields(object map[string]json.RawMessage, selectionSet ast.SelectionSet) bool {
+	for _, selection := range selectionSet {
+		if field, ok := selection.(*ast.Field); ok && w.included(field.Directives) {
+			if _, ok := object[responseKey(field)]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (w *cacheWalker) normalize(raw json.RawMessage, selectionSet ast.SelectionSet) (any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(selectionSet) == 0 || bytes.Equal(raw, []byte("null")) {
+		return json.RawMessage(bytes.Clone(raw)), nil
+	}
+
+	if len(raw) > 0 && raw[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+
+		values := make([]any, 0, len(items))
+		for _, item := range items {
+			value, err := w.normalize(item, selectionSet)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+
+		return values, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	key, ok := entityKey(object)
+	if !ok {
+		record := make(cacheRecord)
+		if err := w.write(object, selectionSet, record); err != nil {
+			return nil, err
+		}
+
+		return record, nil
+	}
+
+	if err := w.write(object, selectionSet, w.cache.entity(key)); err != nil {
+		return nil, err
+	}
+
+	return cacheReference(key), nil
+}
+
+func (w *cacheWalker) read(record cacheRecord, selectionSet ast.SelectionSet, data map[string]any) bool {
+	for _, selection := range selectionSet {
+		var (
+			fragmentSelectionSet ast.SelectionSet
+			typeCondition        string
+		)
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !w.included(selection.Directives) {
+				continue
+			}
+
+			value, ok := record[w.fieldKey(selection)]
+			if !ok {
+				return false
+			}
+
+			result, ok := w.denormalize(value, selection.SelectionSet)
+			if !ok {
+				return false
+			}
+			data[responseKey(selection)] = merge(data[responseKey(selection)], result)
+
+			continue
+		case *ast.InlineFragment:
+			if !w.included(selection.Directives) {
+				continue
+			}
+			fragmentSelectionSet, typeCondition = selection.SelectionSet, selection.TypeCondition
+		case *ast.FragmentSpread:
+			fragment := w.doc.Fragments.ForName(selection.Name)
+			if fragment == nil || !w.included(selection.Directives) {
+				continue
+			}
+			fragmentSelectionSet, typeCondition = fragment.SelectionSet, fragment.TypeCondition
+		}
+
+		// fragments on other types are skipped, unless a response has shown that the type of the record matches them
+		typename := recordTypename(record)
+		if typeCondition != "" && typename != "" && typeCondition != typename && !w.cache.possibleTypes[typeCondition][typename] {
+			continue
+		}
+
+		if !w.read(record, fragmentSelectionSet, data) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w *cacheWalker) denormalize(value any, selectionSet ast.SelectionSet) (any, bool) {
+	switch value := value.(type) {
+	case json.RawMessage:
+		return value, true
+	case cacheReference:
+		record, ok := w.cache.entities[string(value)]
+		if !ok {
+			return nil, false
+		}
+
+		return w.denormalize(record, selectionSet)
+	case cacheRecord:
+		data := make(map[string]any)
+		if !w.read(value, selectionSet, data) {
+			return nil, false
+		}
+
+		return data, true
+	case []any:
+		items := make([]any, 0, len(value))
+		for _, item := range value {
+			result, ok := w.denormalize(item, selectionSet)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, result)
+		}
+
+		return items, true
+	default:
+		return nil, false
+	}
+}
+
+// fieldKey identifies a field in a record by its name and the values of its arguments.
+func (w *cacheWalker) fieldKey(field *ast.Field) string {
+	if len(field.Arguments) == 0 {
+		return field.Name
+	}
+
+	args := make(map[string]any, len(field.Arguments))
+	for _, arg := range field.Arguments {
+		value, err := arg.Value.Value(w.vars)
+		if err != nil {
+			value = arg.Value.String()
+		}
+		args[arg.Name] = value
+	}
+
+	encoded, err := MarshalJSON(context.Background(), args)
+	if err != nil {
+		return field.Name + "(" + fmt.Sprint(args) + ")"
+	}
+
+	return field.Name + "(" + string(encoded) + ")"
+}
+
+// included evaluates the @skip and @include directives.
+func (w *cacheWalker) included(directives ast.DirectiveList) bool {
+	condition := func(name string) (bool, bool) {
+		directive := directives.ForName(name)
+		if directive == nil {
+			return false, false
+		}
+
+		arg := directive.Arguments.ForName("if")
+		if arg == nil {
+			return false, false
+		}
+
+		value, err := arg.Value.Value(w.vars)
+		if err != nil {
+			return false, false
+		}
+
+		b, ok := value.(bool)
+
+		return b, ok
+	}
+
+	if skip, ok := condition("skip"); ok && skip {
+		return false
+	}
+	if include, ok := condition("include"); ok && !include {
+		return false
+	}
+
+	return true
+}
+
+func responseKey(field *ast.Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+
+	return field.Name
+}
+
+// The response keys of __typename and id when the generator selects them under an alias, because another field
+// is selected as __typename or id.
+const (
+	CacheTypenameAlias = "_gqlgencTypename"
+	CacheIDAlias       = "_gqlgencId"
+)
+
+// keyField returns the value of the key field name of object, selected under alias or as name.
+func keyField(object map[string]json.RawMessage, name, alias string) (json.RawMessage, bool) {
+	if raw, ok := object[alias]; ok {
+		return raw, true
+	}
+
+	raw, ok := object[name]
+
+	return raw, ok
+}
+
+// entityKey returns the key of an object with a __typename and an id.
+func entityKey(object map[string]json.RawMessage) (string, bool) {
+	typename := objectTypename(object)
+	if typename == "" {
+		return "", false
+	}
+
+	raw, ok := keyField(object, "id", CacheIDAlias)
+	if !ok {
+		return "", false
+	}
+
+	var id any
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return "", false
+	}
+
+	switch id := id.(type) {
+	case string:
+		return typename + ":" + id, true
+	case float64:
+		return typename + ":" + string(bytes.TrimSpace(raw)), true
+	default:
+		return "", false
+	}
+}
+
+func objectTypename(object map[string]json.RawMessage) string {
+	raw, _ := keyField(object, "__typename", CacheTypenameAlias)
+
+	var typename string
+	_ = json.Unmarshal(raw, &typename)
+
+	return typename
+}
+
+func recordTypename(record cacheRecord) string {
+	raw, ok := record["__typename"].(json.RawMessage)
+	if !ok {
+		return ""
+	}
+
+	var typename string
+	_ = json.Unmarshal(raw, &typename)
+
+	return typename
+}
+
+// merge combines the data of a field selected several times, such as in the operation and in a fragment.
+func merge(existing, value any) any {
+	switch existing := existing.(type) {
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		for key, v := range object {
+			existing[key] = merge(existing[key], v)
+		}
+
+		return existing
+	case []any:
+		items, ok := value.([]any)
+		if !ok || len(items) != len(existing) {
+			return value
+		}
+
+		for i, item := range items {
+			existing[i] = merge(existing[i], item)
+		}
+
+		return existing
+	default:
+		return value
+	}
+}
@@ -0,0 +1,228 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cacheTodo struct {
+	Typename string `json:"__typename" graphql:"__typename"`
+	ID       string `json:"id" graphql:"id"`
+	Text     string `json:"text" graphql:"text"`
+}
+
+type cacheTodosRes struct {
+	Todos []*cacheTodo `json:"todos" graphql:"todos"`
+}
+
+type cacheTodoRes struct {
+	Todo *cacheTodo `json:"todo" graphql:"todo"`
+}
+
+type cacheUpdateTodoRes struct {
+	UpdateTodo *cacheTodo `json:"updateTodo" graphql:"updateTodo"`
+}
+
+// respondByOperation answers each operation with its response in responses.
+func respondByOperation(t *testing.T, responses map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		respondWithJSON(responses[body.OperationName])(w, r)
+	}
+}
+
+// operationNames returns the names of the operations of the requests.
+func operationNames(t *testing.T, requests []recordedRequest) []string {
+	t.Helper()
+
+	names := make([]string, 0, len(requests))
+	for _, req := range requests {
+		names = append(names, req.graphQLRequest(t).OperationName)
+	}
+
+	return names
+}
+
+func TestNewCacheInterceptor(t *testing.T) {
+	t.Parallel()
+
+	const (
+		todosQuery    = "query Todos { todos { __typename id text } }"
+		todoQuery     = "query Todo($id: ID!) { todo(id: $id) { __typename id text } }"
+		updateTodo    = `mutation UpdateTodo { updateTodo(id: "1", text: "updated") { __typename id text } }`
+		todosResponse = `{"data":{"todos":[{"__typename":"Todo","id":"1","text":"first"},{"__typename":"Todo","id":"2","text":"second"}]}}`
+	)
+
+	t.Run("cache first", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondByOperation(t, map[string]string{"Todos": todosResponse}))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewCacheInterceptor(NewCache(), CachePolicyCacheFirst))
+
+		var first, second cacheTodosRes
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &first, nil))
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &second, nil))
+		require.Equal(t, first, second)
+		require.Equal(t, "second", second.Todos[1].Text)
+		require.Equal(t, []string{"Todos"}, operationNames(t, srv.requests()))
+	})
+
+	t.Run("entities are shared between queries and updated by mutations", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondByOperation(t, map[string]string{
+			"Todos":      todosResponse,
+			"Todo":       `{"data":{"todo":{"__typename":"Todo","id":"2","text":"second"}}}`,
+			"UpdateTodo": `{"data":{"updateTodo":{"__typename":"Todo","id":"1","text":"updated"}}}`,
+		}))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewCacheInterceptor(NewCache(), CachePolicyCacheFirst))
+
+		var todos cacheTodosRes
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &todos, nil))
+
+		var todo cacheTodoRes
+		require.NoError(t, c.Post(context.Background(), "Todo", todoQuery, &todo, map[string]any{"id": "2"}))
+		require.Equal(t, "second", todo.Todo.Text)
+
+		var updated cacheUpdateTodoRes
+		require.NoError(t, c.Post(context.Background(), "UpdateTodo", updateTodo, &updated, nil))
+
+		todos = cacheTodosRes{}
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &todos, nil))
+		require.Equal(t, "updated", todos.Todos[0].Text)
+
+		// the field is cached by its arguments, todo(id: "2") does not answer todo(id: "1")
+		todo = cacheTodoRes{}
+		require.NoError(t, c.Post(context.Background(), "Todo", todoQuery, &todo, map[string]any{"id": "2"}))
+		require.Equal(t, []string{"Todos", "Todo", "UpdateTodo"}, operationNames(t, srv.requests()))
+		require.NoError(t, c.Post(context.Background(), "Todo", todoQuery, &todo, map[string]any{"id": "1"}))
+		require.Equal(t, []string{"Todos", "Todo", "UpdateTodo", "Todo"}, operationNames(t, srv.requests()))
+	})
+
+	t.Run("network only", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondByOperation(t, map[string]string{"Todos": todosResponse}))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewCacheInterceptor(NewCache(), CachePolicyCacheFirst))
+
+		var res cacheTodosRes
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &res, nil))
+		require.NoError(t, c.Post(WithCachePolicy(context.Background(), CachePolicyNetworkOnly), "Todos", todosQuery, &res, nil))
+		require.Equal(t, []string{"Todos", "Todos"}, operationNames(t, srv.requests()))
+	})
+
+	t.Run("cache and network", func(t *testing.T) {
+		t.Parallel()
+		responses := map[string]string{"Todos": todosResponse}
+		srv := newTestServer(t, respondByOperation(t, responses))
+		cache := NewCache()
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewCacheInterceptor(cache, CachePolicyCacheAndNetwork))
+
+		var res cacheTodosRes
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &res, nil))
+
+		responses["Todos"] = `{"data":{"todos":[{"__typename":"Todo","id":"1","text":"refreshed"}]}}`
+		res = cacheTodosRes{}
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &res, nil))
+		require.Equal(t, "first", res.Todos[0].Text)
+
+		require.Eventually(t, func() bool {
+			return len(srv.requests()) == 2
+		}, time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool {
+			data, ok := cache.Read(describeOperation("Todos", todosQuery), nil)
+
+			return ok && string(data) == `{"todos":[{"__typename":"Todo","id":"1","text":"refreshed"}]}`
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("queries selecting uncached fields are sent", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondByOperation(t, map[string]string{
+			"Todos":     todosResponse,
+			"TodosDone": `{"data":{"todos":[{"__typename":"Todo","id":"1","done":true}]}}`,
+		}))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewCacheInterceptor(NewCache(), CachePolicyCacheFirst))
+
+		var res cacheTodosRes
+		require.NoError(t, c.Post(context.Background(), "Todos", todosQuery, &res, nil))
+		_ = c.Post(context.Background(), "TodosDone", "query TodosDone { todos { __typename id done } }", &struct{}{}, nil)
+		require.Equal(t, []string{"Todos", "TodosDone"}, operationNames(t, srv.requests()))
+	})
+}
+
+func TestCache_Read(t *testing.T) {
+	t.Parallel()
+
+	const query = `query Search($first: Int, $withText: Boolean!) {
+	search(first: $first) {
+		__typename
+		... on Todo { id text @include(if: $withText) }
+		...UserFields
+	}
+	viewer { name: login settings { theme } }
+}
+fragment UserFields on User { id login }`
+
+	operation := describeOperation("Search", query)
+	vars := map[string]any{"first": 2, "withText": true}
+
+	cache := NewCache()
+	require.NoError(t, cache.Write(operation, vars, json.RawMessage(`{
+		"search": [
+			{"__typename": "Todo", "id": "1", "text": "first"},
+			{"__typename": "User", "id": "1", "login": "octocat"}
+		],
+		"viewer": {"name": "octocat", "settings": {"theme": "dark"}}
+	}`)))
+
+	data, ok := cache.Read(operation, vars)
+	require.True(t, ok)
+	require.JSONEq(t, `{
+		"search": [
+			{"__typename": "Todo", "id": "1", "text": "first"},
+			{"__typename": "User", "id": "1", "login": "octocat"}
+		],
+		"viewer": {"name": "octocat", "settings": {"theme": "dark"}}
+	}`, string(data))
+
+	_, ok = cache.Read(operation, map[string]any{"first": 3, "withText": true})
+	require.False(t, ok, "arguments are part of the cache key")
+
+	cache.Evict("User", "1")
+	_, ok = cache.Read(operation, vars)
+	require.False(t, ok, "evicted entities are missing")
+
+	cache.Reset()
+	_, ok = cache.Read(operation, vars)
+	require.False(t, ok)
+}
+
+func TestCache_aliasedKeyFields(t *testing.T) {
+	t.Parallel()
+
+	const query = `query Viewer { viewer { id: login __typename _gqlgencId: id } }`
+	const login = `query Login { viewer { __typename id login } }`
+
+	cache := NewCache()
+	require.NoError(t, cache.Write(describeOperation("Viewer", query), nil, json.RawMessage(`{
+		"viewer": {"id": "octocat", "__typename": "User", "_gqlgencId": "1"}
+	}`)))
+
+	data, ok := cache.Read(describeOperation("Viewer", query), nil)
+	require.True(t, ok)
+	require.JSONEq(t, `{"viewer": {"id": "octocat", "__typename": "User", "_gqlgencId": "1"}}`, string(data))
+
+	// the entity is keyed by its id, not by the field selected as id
+	data, ok = cache.Read(describeOperation("Login", login), nil)
+	require.True(t, ok)
+	require.JSONEq(t, `{"viewer": {"__typename": "User", "id": "1", "login": "octocat"}}`, string(data))
+
+	cache.Evict("User", "1")
+	_, ok = cache.Read(describeOperation("Viewer", query), nil)
+	require.False(t, ok)
+}
@@ -15,9 +15,12 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/Yamashou/gqlgenc/graphqljson"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
@@ -28,11 +31,21 @@ type HttpClient interface {
 
 type GQLRequestInfo struct {
 	Request *Request
+	// Operation describes the operation of Request.
+	Operation *Operation
+	// ResponseBody is the body of the response, set once the request is sent for the interceptors to read after next returns.
+	// It is not set when CustomDo sends the request.
+	ResponseBody []byte
+	// ResponseStatusCode is the HTTP status code of the response, set along with ResponseBody.
+	ResponseStatusCode int
+	// Batch holds every operation of a batch request sent by PostBatch, Request is then the first one.
+	Batch []*Request
 }
 
 func NewGQLRequestInfo(r *Request) *GQLRequestInfo {
 	return &GQLRequestInfo{
-		Request: r,
+		Request:   r,
+		Operation: r.Operation,
 	}
 }
 
@@ -88,6 +101,19 @@ type Client struct {
 	CustomDo                   RequestInterceptorFunc
 	ParseDataWhenErrors        bool
 	IsUnsafeRequestInterceptor bool
+	SubscriptionTransport      SubscriptionTransport
+	WebsocketURL               string
+	WebsocketInitPayload       map[string]any
+	PersistedQueries           bool
+	PersistedQueriesUseGET     bool
+	PersistedQueriesOnly       bool
+	RequestMode                RequestMode
+	MaxGETURLLength            int
+
+	batcher    *batcher
+	operations *lru.Cache[operationKey, *Operation]
+	// persistedQueriesNotSupported is set once the server answered that it does not support persisted queries.
+	persistedQueriesNotSupported atomic.Bool
 }
 
 // Request represents an outgoing GraphQL request
@@ -95,6 +121,9 @@ type Request struct {
 	Query         string         `json:"query"`
 	Variables     map[string]any `json:"variables,omitempty"`
 	OperationName string         `json:"operationName,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
+	// Operation describes the operation, it is not sent.
+	Operation *Operation `json:"-"`
 }
 
 // NewClient creates a new http client wrapper
@@ -105,11 +134,10 @@ func NewClient(client HttpClient, baseURL string, options *Options, interceptors
 		RequestInterceptor: ChainInterceptor(append([]RequestInterceptor{func(ctx context.Context, requestSet *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
 			return next(ctx, requestSet, gqlInfo, res)
 		}}, interceptors...)...),
+		operations: newOperationCache(),
 	}
 
-	if options != nil {
-		c.ParseDataWhenErrors = options.ParseDataAlongWithErrors
-	}
+	c.applyOptions(options)
 
 	return c
 }
@@ -122,11 +150,10 @@ func NewClientWithUnsafeRequestInterceptor(client HttpClient, baseURL string, op
 			return next(ctx, requestSet, gqlInfo, res)
 		}}, interceptors...)...),
 		IsUnsafeRequestInterceptor: true,
+		operations:                 newOperationCache(),
 	}
 
-	if options != nil {
-		c.ParseDataWhenErrors = options.ParseDataAlongWithErrors
-	}
+	c.applyOptions(options)
 
 	return c
 }
@@ -136,6 +163,69 @@ type Options struct {
 	// ParseDataAlongWithErrors is a flag that indicates whether the client should try to parse and return the data along with error
 	// when error appeared. So in the end you'll get list of gql errors and data.
 	ParseDataAlongWithErrors bool
+
+	// SubscriptionTransport selects the protocol used for subscription operations.
+	// The default is SubscriptionTransportWebsocket.
+	SubscriptionTransport SubscriptionTransport
+
+	// WebsocketURL is the endpoint used for subscriptions over graphql-transport-ws.
+	// When empty, BaseURL is used with its scheme replaced by ws or wss.
+	WebsocketURL string
+
+	// WebsocketInitPayload is sent as the payload of the connection_init message,
+	// commonly used by servers for authentication.
+	WebsocketInitPayload map[string]any
+
+	// PersistedQueries enables Automatic Persisted Queries: operations are first sent with only the
+	// SHA-256 hash of their document, and sent again with the document when the server does not know the hash.
+	// Once the server answers that it does not support persisted queries, only the documents are sent.
+	PersistedQueries bool
+
+	// PersistedQueriesUseGET sends the requests carrying only the hash as GET requests, so they can be cached
+	// by CDNs. Mutations are always sent as POST requests.
+	PersistedQueriesUseGET bool
+
+	// PersistedQueriesOnly sends only the SHA-256 hash of the operations, which is their id in the manifest
+	// written by the generator, and never their document. It is meant for servers that only accept known operations.
+	PersistedQueriesOnly bool
+
+	// RequestMode selects the HTTP method used to send queries. It can be overridden per call with WithRequestMode.
+	RequestMode RequestMode
+
+	// MaxGETURLLength is the longest URL sent as a GET request, longer requests are sent as POST requests.
+	// The default is DefaultMaxGETURLLength.
+	MaxGETURLLength int
+
+	// BatchWindow enables automatic batching: the Post calls made within this duration are sent together
+	// in one request by PostBatch. Calls with their own interceptors, GET requests, persisted queries and
+	// uploads are not batched. Only calls with the same batch key, set by WithBatchKey, are batched together,
+	// and the interceptors of a batch get the values of the context of its first call, so calls with different
+	// credentials must have different batch keys. A batch is cancelled once all its calls are, and its deadline
+	// is the latest of theirs. Batching fails when CustomDo is set.
+	BatchWindow time.Duration
+
+	// MaxBatchSize sends a batch as soon as it has this many operations. Zero means no limit.
+	MaxBatchSize int
+}
+
+func (c *Client) applyOptions(options *Options) {
+	if options == nil {
+		return
+	}
+
+	c.ParseDataWhenErrors = options.ParseDataAlongWithErrors
+	c.SubscriptionTransport = options.SubscriptionTransport
+	c.WebsocketURL = options.WebsocketURL
+	c.WebsocketInitPayload = options.WebsocketInitPayload
+	c.PersistedQueries = options.PersistedQueries
+	c.PersistedQueriesUseGET = options.PersistedQueriesUseGET
+	c.PersistedQueriesOnly = options.PersistedQueriesOnly
+	c.RequestMode = options.RequestMode
+	c.MaxGETURLLength = options.MaxGETURLLength
+
+	if options.BatchWindow > 0 {
+		c.batcher = newBatcher(c, options.BatchWindow, options.MaxBatchSize)
+	}
 }
 
 // GqlErrorList is the struct of a standard graphql error response
@@ -151,6 +241,12 @@ func (e *GqlErrorList) Error() string {
 type HTTPError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// Header holds the headers of the response, such as Retry-After.
+	Header http.Header `json:"-"`
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http status %d: %s", e.Code, e.Message)
 }
 
 // ErrorResponse represent an handled error
@@ -175,6 +271,19 @@ func (er *ErrorResponse) Error() string {
 	return string(content)
 }
 
+// Unwrap returns NetworkError and GqlErrors, so that errors.As finds an *HTTPError or a *gqlerror.Error in an ErrorResponse.
+func (er *ErrorResponse) Unwrap() []error {
+	var errs []error
+	if er.NetworkError != nil {
+		errs = append(errs, er.NetworkError)
+	}
+	if er.GqlErrors != nil {
+		errs = append(errs, *er.GqlErrors)
+	}
+
+	return errs
+}
+
 type MultipartFile struct {
 	File  graphql.Upload
 	Index int
@@ -196,26 +305,93 @@ type header struct {
 
 // Post support send multipart form with files https://gqlgen.com/reference/file-upload/ https://github.com/jaydenseric/graphql-multipart-request-spec
 func (c *Client) Post(ctx context.Context, operationName, query string, respData any, vars map[string]any, interceptors ...RequestInterceptor) error {
-	multipartFilesGroups, mapping, vars := parseMultipartFiles(vars)
+	return c.PostOperation(ctx, c.describeOperation(operationName, query), respData, vars, interceptors...)
+}
+
+// PostWithHash is Post for callers that already know the SHA-256 hash of query,
+// so that it is not computed for every request when PersistedQueries is enabled.
+// An empty queryHash is computed with DocumentHash when needed.
+func (c *Client) PostWithHash(ctx context.Context, operationName, query, queryHash string, respData any, vars map[string]any, interceptors ...RequestInterceptor) error {
+	operation := c.describeOperation(operationName, query)
+	if queryHash != "" && queryHash != operation.DocumentHash {
+		// the described operations are shared, the hash is set on a copy
+		hashed := *operation
+		hashed.DocumentHash = queryHash
+		operation = &hashed
+	}
+
+	return c.PostOperation(ctx, operation, respData, vars, interceptors...)
+}
 
+// PostOperation is Post for callers that describe the operation, as generated clients do,
+// so that it is neither parsed nor hashed for every request.
+func (c *Client) PostOperation(ctx context.Context, operation *Operation, respData any, vars map[string]any, interceptors ...RequestInterceptor) error {
 	r := &Request{
-		Query:         query,
+		Query:         operation.Document,
 		Variables:     vars,
-		OperationName: operationName,
+		OperationName: operation.Name,
+		Operation:     operation,
+	}
+
+	if c.PersistedQueriesOnly {
+		return c.postHashed(ctx, r, respData, interceptors)
+	}
+
+	// uploaded files can only be read once, so they are never sent with the hash alone
+	if c.PersistedQueries && !hasUploads(vars) && !c.persistedQueriesNotSupported.Load() {
+		return c.postPersisted(ctx, r, respData, interceptors)
+	}
+
+	if c.batcher != nil && len(interceptors) == 0 && c.requestMode(ctx) == RequestModePOST && !hasUploads(vars) {
+		return c.batcher.do(ctx, r, respData)
 	}
 
+	return c.postOrGet(ctx, r, c.requestMode(ctx) == RequestModeGET, respData, interceptors)
+}
+
+func (c *Client) post(ctx context.Context, r *Request, respData any, interceptors []RequestInterceptor) error {
+	gqlInfo, req, err := c.newRequest(ctx, r, "application/json; charset=utf-8")
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, req, gqlInfo, respData, interceptors)
+}
+
+func (c *Client) send(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, respData any, interceptors []RequestInterceptor) error {
+	f := c.chainInterceptors(interceptors)
+
+	// if custom do is set, use it instead of the default one
+	if c.CustomDo != nil {
+		return f(ctx, req, gqlInfo, respData, c.CustomDo)
+	}
+
+	return f(ctx, req, gqlInfo, respData, c.do)
+}
+
+// newRequest builds the http request for an operation. accept negotiates the response format,
+// it is only sent with JSON bodies since multipart requests always get a single JSON response.
+func (c *Client) newRequest(ctx context.Context, r *Request, accept string) (*GQLRequestInfo, *http.Request, error) {
+	multipartFilesGroups, mapping, vars := parseMultipartFiles(r.Variables)
+	r.Variables = vars
+
 	gqlInfo := NewGQLRequestInfo(r)
 	body := new(bytes.Buffer)
 
 	var headers []header
 
+	var payload any = r
+	if r.Query == "" {
+		payload = hashedRequest{Variables: r.Variables, OperationName: r.OperationName, Extensions: r.Extensions}
+	}
+
 	if len(multipartFilesGroups) > 0 {
 		contentType, err := prepareMultipartFormBody(
 			body,
 			[]FormField{
 				{
 					Name:  "operations",
-					Value: r,
+					Value: payload,
 				},
 				{
 					Name:  "map",
@@ -225,42 +401,40 @@ func (c *Client) Post(ctx context.Context, operationName, query string, respData
 			multipartFilesGroups,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to prepare form body: %w", err)
+			return nil, nil, fmt.Errorf("failed to prepare form body: %w", err)
 		}
 
 		headers = append(headers, header{key: "Content-Type", value: contentType})
 	} else {
-		requestBody, err := MarshalJSON(ctx, r)
+		requestBody, err := MarshalJSON(ctx, payload)
 		if err != nil {
-			return fmt.Errorf("encode: %w", err)
+			return nil, nil, fmt.Errorf("encode: %w", err)
 		}
 
 		body = bytes.NewBuffer(requestBody)
 
 		headers = append(headers, header{key: "Content-Type", value: "application/json; charset=utf-8"})
-		headers = append(headers, header{key: "Accept", value: "application/json; charset=utf-8"})
+		headers = append(headers, header{key: "Accept", value: accept})
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL, body)
 	if err != nil {
-		return fmt.Errorf("create request struct failed: %w", err)
+		return nil, nil, fmt.Errorf("create request struct failed: %w", err)
 	}
 
 	for _, h := range headers {
 		req.Header.Set(h.key, h.value)
 	}
 
-	f := ChainInterceptor(append([]RequestInterceptor{c.RequestInterceptor}, interceptors...)...)
-	if c.IsUnsafeRequestInterceptor {
-		f = UnsafeChainInterceptor(append([]RequestInterceptor{c.RequestInterceptor}, interceptors...)...)
-	}
+	return gqlInfo, req, nil
+}
 
-	// if custom do is set, use it instead of the default one
-	if c.CustomDo != nil {
-		return f(ctx, req, gqlInfo, respData, c.CustomDo)
+func (c *Client) chainInterceptors(interceptors []RequestInterceptor) RequestInterceptor {
+	if c.IsUnsafeRequestInterceptor {
+		return UnsafeChainInterceptor(append([]RequestInterceptor{c.RequestInterceptor}, interceptors...)...)
 	}
 
-	return f(ctx, req, gqlInfo, respData, c.do)
+	return ChainInterceptor(append([]RequestInterceptor{c.RequestInterceptor}, interceptors...)...)
 }
 
 func parseMultipartFiles(
@@ -376,26 +550,45 @@ func prepareMultipartFormBody(
 	return writer.FormDataContentType(), nil
 }
 
-func (c *Client) do(_ context.Context, req *http.Request, _ *GQLRequestInfo, res any) error {
+func (c *Client) do(_ context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any) error {
+	body, resp, err := c.roundTrip(req)
+	if err != nil {
+		return err
+	}
+	gqlInfo.ResponseBody = body
+	gqlInfo.ResponseStatusCode = resp.StatusCode
+
+	err = checkSemanticNonNull(gqlInfo.Operation, body, c.parseResponse(body, resp.StatusCode, res))
+
+	var errResponse *ErrorResponse
+	if errors.As(err, &errResponse) && errResponse.NetworkError != nil {
+		errResponse.NetworkError.Header = resp.Header
+	}
+
+	return err
+}
+
+// roundTrip sends req and returns the response body along with the response, whose body is already closed.
+func (c *Client) roundTrip(req *http.Request) ([]byte, *http.Response, error) {
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", &TransportError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		resp.Body, err = gzip.NewReader(resp.Body)
 		if err != nil {
-			return fmt.Errorf("gzip decode failed: %w", err)
+			return nil, nil, fmt.Errorf("gzip decode failed: %w", err)
 		}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", &TransportError{Err: err})
 	}
 
-	return c.parseResponse(body, resp.StatusCode, res)
+	return body, resp, nil
 }
 
 func (c *Client) parseResponse(body []byte, httpCode int, result any) error {
@@ -434,7 +627,7 @@ type response struct {
 func (c *Client) unmarshal(data []byte, res any) error {
 	resp := response{}
 	if err := json.Unmarshal(data, &resp); err != nil {
-		return fmt.Errorf("failed to decode data %s: %w", string(data), err)
+		return fmt.Errorf("failed to decode data %s: %w", string(data), &DecodeError{Body: data, Err: err})
 	}
 
 	var err error
@@ -442,7 +635,7 @@ func (c *Client) unmarshal(data []byte, res any) error {
 		// try to parse standard graphql error
 		err = &GqlErrorList{}
 		if e := json.Unmarshal(data, err); e != nil {
-			return fmt.Errorf("faild to parse graphql errors. Response content %s - %w", string(data), e)
+			return fmt.Errorf("faild to parse graphql errors. Response content %s - %w", string(data), &DecodeError{Body: data, Err: e})
 		}
 
 		// if ParseDataWhenErrors is true, try to parse data as well
@@ -457,7 +650,7 @@ func (c *Client) unmarshal(data []byte, res any) error {
 			return err
 		}
 
-		return fmt.Errorf("failed to decode data into response %s: %w", string(data), errData)
+		return fmt.Errorf("failed to decode data into response %s: %w", string(data), &DecodeError{Body: data, Err: errData})
 	}
 
 	return err
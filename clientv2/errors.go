@@ -0,0 +1,195 @@
+package clientv2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// The errors returned by the client can be inspected with errors.As:
+//   - *TransportError when the request cannot be sent or its response cannot be read,
+//   - *HTTPError when the server answers with a non 2xx status,
+//   - *DecodeError when the response cannot be decoded,
+//   - *gqlerror.Error when the server returns GraphQL errors.
+//
+// *HTTPError and GraphQL errors are both reported in an *ErrorResponse, since some servers send GraphQL errors with a non 2xx status.
+
+// TransportError is the error when a request cannot be sent or its response cannot be read.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is the error when a response, or a part of it, cannot be decoded.
+type DecodeError struct {
+	// Body is the content that failed to decode.
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// GraphQLErrors returns the GraphQL errors reported in err, or nil when there are none.
+func GraphQLErrors(err error) gqlerror.List {
+	var errResponse *ErrorResponse
+	if !errors.As(err, &errResponse) || errResponse.GqlErrors == nil {
+		return nil
+	}
+
+	return *errResponse.GqlErrors
+}
+
+// HasErrorCode reports whether err reports a GraphQL error whose extensions.code is code.
+func HasErrorCode(err error, code string) bool {
+	return len(ErrorsWithCode(GraphQLErrors(err), code)) > 0
+}
+
+// ErrorsWithCode returns the errors of errs whose extensions.code is code.
+func ErrorsWithCode(errs gqlerror.List, code string) gqlerror.List {
+	return filterErrors(errs, func(gqlErr *gqlerror.Error) bool {
+		return ErrorCode(gqlErr) == code
+	})
+}
+
+// ErrorsAtPath returns the errors of errs whose path is path or is below it,
+// so that the path "viewer" matches the errors of ["viewer", "repositories", 0, "name"].
+func ErrorsAtPath(errs gqlerror.List, path ast.Path) gqlerror.List {
+	return filterErrors(errs, func(gqlErr *gqlerror.Error) bool {
+		if len(gqlErr.Path) < len(path) {
+			return false
+		}
+
+		for i, element := range path {
+			if gqlErr.Path[i] != element {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// ErrorsForField returns the errors of errs that belong to the field at the dot separated path of response keys,
+// such as "viewer.repositories", or to its subfields. List indexes are ignored, so that "viewer.repositories.name"
+// matches the errors of the names of all the repositories.
+func ErrorsForField(errs gqlerror.List, field string) gqlerror.List {
+	keys := strings.Split(field, ".")
+
+	return filterErrors(errs, func(gqlErr *gqlerror.Error) bool {
+		var names []string
+		for _, element := range gqlErr.Path {
+			if name, ok := element.(ast.PathName); ok {
+				names = append(names, string(name))
+			}
+		}
+
+		if len(names) < len(keys) {
+			return false
+		}
+
+		for i, key := range keys {
+			if names[i] != key {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func filterErrors(errs gqlerror.List, match func(*gqlerror.Error) bool) gqlerror.List {
+	var matched gqlerror.List
+	for _, gqlErr := range errs {
+		if gqlErr != nil && match(gqlErr) {
+			matched = append(matched, gqlErr)
+		}
+	}
+
+	return matched
+}
+
+// ErrorCode returns the extensions.code of gqlErr, or "" when it has none.
+func ErrorCode(gqlErr *gqlerror.Error) string {
+	code, _ := gqlErr.Extensions["code"].(string)
+
+	return code
+}
+
+// DecodeExtensions decodes the extensions of gqlErr into v, usually a pointer to a struct with json tags.
+func DecodeExtensions(gqlErr *gqlerror.Error, v any) error {
+	data, err := json.Marshal(gqlErr.Extensions)
+	if err != nil {
+		return fmt.Errorf("encode extensions: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode extensions: %w", err)
+	}
+
+	return nil
+}
+
+// ErrorsWithCode returns the GraphQL errors whose extensions.code is code.
+func (er *ErrorResponse) ErrorsWithCode(code string) gqlerror.List {
+	return ErrorsWithCode(er.graphQLErrors(), code)
+}
+
+// ErrorsAtPath returns the GraphQL errors whose path is path or is below it.
+func (er *ErrorResponse) ErrorsAtPath(path ast.Path) gqlerror.List {
+	return ErrorsAtPath(er.graphQLErrors(), path)
+}
+
+// ErrorsForField returns the GraphQL errors of the field at the dot separated path of response keys, or of its subfields.
+func (er *ErrorResponse) ErrorsForField(field string) gqlerror.List {
+	return ErrorsForField(er.graphQLErrors(), field)
+}
+
+func (er *ErrorResponse) graphQLErrors() gqlerror.List {
+	if er == nil || er.GqlErrors == nil {
+		return nil
+	}
+
+	return *er.GqlErrors
+}
+
+// ResponseErrors are the GraphQL errors of a response, returned by the Errors method of the generated responses
+// to tell which of their fields are null because of an error, such as res.Errors().For("viewer.repositories").
+type ResponseErrors gqlerror.List
+
+// NewResponseErrors returns the GraphQL errors reported in err.
+func NewResponseErrors(err error) ResponseErrors {
+	return ResponseErrors(GraphQLErrors(err))
+}
+
+// For returns the errors of the field at the dot separated path of response keys, which are the json names of the
+// generated fields, or of its subfields. List indexes are ignored.
+func (e ResponseErrors) For(field string) gqlerror.List {
+	return ErrorsForField(gqlerror.List(e), field)
+}
+
+// At returns the errors whose path is path or is below it.
+func (e ResponseErrors) At(path ast.Path) gqlerror.List {
+	return ErrorsAtPath(gqlerror.List(e), path)
+}
+
+// WithCode returns the errors whose extensions.code is code.
+func (e ResponseErrors) WithCode(code string) gqlerror.List {
+	return ErrorsWithCode(gqlerror.List(e), code)
+}
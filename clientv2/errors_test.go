@@ -0,0 +1,109 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errorsResponse = `{"errors":[
+	{"message":"not authenticated","path":["viewer"],"extensions":{"code":"UNAUTHENTICATED"}},
+	{"message":"rate limited","path":["viewer","repositories",0,"name"],"extensions":{"code":"RATE_LIMITED","retryAfter":30}},
+	{"message":"rate limited","path":["viewer","repositories",1,"name"],"extensions":{"code":"RATE_LIMITED","retryAfter":60}},
+	{"message":"no path"}
+]}`
+
+func TestErrorResponse_lookup(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	err := c.parseResponse([]byte(errorsResponse), http.StatusOK, &struct{}{})
+
+	var errResponse *ErrorResponse
+	require.ErrorAs(t, err, &errResponse)
+	require.Len(t, GraphQLErrors(err), 4)
+
+	var gqlErr *gqlerror.Error
+	require.ErrorAs(t, err, &gqlErr)
+	require.Equal(t, "not authenticated", gqlErr.Message)
+	require.False(t, errors.As(err, new(*HTTPError)))
+
+	require.True(t, HasErrorCode(err, "UNAUTHENTICATED"))
+	require.False(t, HasErrorCode(err, "FORBIDDEN"))
+	require.Len(t, errResponse.ErrorsWithCode("RATE_LIMITED"), 2)
+
+	require.Len(t, errResponse.ErrorsAtPath(ast.Path{ast.PathName("viewer")}), 3)
+	require.Len(t, errResponse.ErrorsAtPath(ast.Path{ast.PathName("viewer"), ast.PathName("repositories"), ast.PathIndex(1)}), 1)
+	require.Empty(t, errResponse.ErrorsAtPath(ast.Path{ast.PathName("repositories")}))
+
+	require.Len(t, errResponse.ErrorsForField("viewer.repositories"), 2)
+	require.Len(t, errResponse.ErrorsForField("viewer.repositories.name"), 2)
+	require.Empty(t, errResponse.ErrorsForField("viewer.login"))
+
+	var extensions struct {
+		Code       string `json:"code"`
+		RetryAfter int    `json:"retryAfter"`
+	}
+	require.NoError(t, DecodeExtensions(errResponse.ErrorsForField("viewer.repositories")[1], &extensions))
+	require.Equal(t, "RATE_LIMITED", extensions.Code)
+	require.Equal(t, 60, extensions.RetryAfter)
+
+	require.Nil(t, GraphQLErrors(errors.New("other")))
+	require.Nil(t, (*ErrorResponse)(nil).ErrorsForField("viewer"))
+}
+
+func TestErrorTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non 2xx status", func(t *testing.T) {
+		t.Parallel()
+		c := &Client{}
+		err := c.parseResponse([]byte("unavailable"), http.StatusServiceUnavailable, &struct{}{})
+
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+		require.Nil(t, GraphQLErrors(err))
+	})
+
+	t.Run("decode failure", func(t *testing.T) {
+		t.Parallel()
+		c := &Client{}
+		err := c.parseResponse([]byte("invalid"), http.StatusOK, &struct{}{})
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "invalid", string(decodeErr.Body))
+	})
+
+	t.Run("transport failure", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient(http.DefaultClient, "http://127.0.0.1:0", nil)
+		err := c.Post(context.Background(), "Name", "query Name { name }", &struct{}{}, nil)
+
+		require.ErrorAs(t, err, new(*TransportError))
+		require.False(t, errors.As(err, new(*ErrorResponse)))
+	})
+}
+
+func TestResponseErrors(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	errs := NewResponseErrors(c.parseResponse([]byte(errorsResponse), http.StatusOK, &struct{}{}))
+
+	require.Len(t, errs, 4)
+	require.Len(t, errs.For("viewer"), 3)
+	require.Len(t, errs.For("viewer.repositories"), 2)
+	require.Empty(t, errs.For("viewer.login"))
+	require.Len(t, errs.At(ast.Path{ast.PathName("viewer"), ast.PathName("repositories"), ast.PathIndex(0)}), 1)
+	require.Len(t, errs.WithCode("UNAUTHENTICATED"), 1)
+
+	require.Nil(t, NewResponseErrors(nil))
+	require.Empty(t, NewResponseErrors(nil).For("viewer"))
+}
@@ -0,0 +1,317 @@
+// Package fixture serves canned or generated GraphQL responses to clients in tests, and records them from a real server.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/goccy/go-yaml"
+)
+
+// Fixture is the response of an operation, served to the requests with the same operation name and variables.
+type Fixture struct {
+	OperationName string `json:"operationName"`
+	// Variables must be equal to the variables of the request, any variables match when it is nil.
+	Variables map[string]any `json:"variables,omitempty"`
+	// Status is the HTTP status of the response, http.StatusOK when zero.
+	Status int `json:"status,omitempty"`
+	// Response is the body of the response, such as {"data": {...}}.
+	Response json.RawMessage `json:"response"`
+}
+
+// Load reads the fixtures of a JSON or YAML file, a list of Fixture. Files ending with .yaml or .yml are read as YAML.
+func Load(filename string) ([]Fixture, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read fixtures: %w", err)
+	}
+
+	if isYAML(filename) {
+		if content, err = yaml.YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("parse fixtures %s: %w", filename, err)
+		}
+	}
+
+	var fixtures []Fixture
+	if err := json.Unmarshal(content, &fixtures); err != nil {
+		return nil, fmt.Errorf("parse fixtures %s: %w", filename, err)
+	}
+
+	return fixtures, nil
+}
+
+// Save writes fixtures to a JSON or YAML file, which Load reads.
+func Save(filename string, fixtures []Fixture) error {
+	content, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode fixtures: %w", err)
+	}
+
+	if isYAML(filename) {
+		if content, err = yaml.JSONToYAML(content); err != nil {
+			return fmt.Errorf("encode fixtures: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create fixtures directory: %w", err)
+	}
+
+	if err := os.WriteFile(filename, content, 0o644); err != nil {
+		return fmt.Errorf("write fixtures: %w", err)
+	}
+
+	return nil
+}
+
+func isYAML(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Server answers GraphQL requests with the response of the first fixture matching their operation name and variables,
+// preferring the fixtures that declare variables. Requests without a fixture get a 404 status with a GraphQL error.
+// It is an http.Handler for httptest.NewServer, and an http.RoundTripper to be used without network through Client.
+type Server struct {
+	mu       sync.Mutex
+	fixtures []Fixture
+}
+
+// NewServer returns a Server that answers with fixtures.
+func NewServer(fixtures ...Fixture) *Server {
+	return &Server{fixtures: fixtures}
+}
+
+// LoadServer returns a Server that answers with the fixtures of the files, read by Load.
+func LoadServer(filenames ...string) (*Server, error) {
+	s := NewServer()
+	for _, filename := range filenames {
+		fixtures, err := Load(filename)
+		if err != nil {
+			return nil, err
+		}
+		s.Add(fixtures...)
+	}
+
+	return s, nil
+}
+
+// Add adds fixtures, which are matched after the fixtures already added.
+func (s *Server) Add(fixtures ...Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures = append(s.fixtures, fixtures...)
+}
+
+// Client returns an HTTP client whose requests are answered by s, for clientv2.NewClient.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: s}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, s.respond)
+}
+
+// RoundTrip answers req without sending it.
+func (s *Server) RoundTrip(req *http.Request) (*http.Response, error) {
+	return roundTrip(req, s.respond), nil
+}
+
+// respond returns the status and body of the response to r, a JSON array for a batch.
+func (s *Server) respond(r *http.Request) (int, []byte) {
+	operations, batch, err := readOperations(r)
+	if err != nil {
+		return http.StatusBadRequest, errorResponse(err.Error())
+	}
+
+	if !batch {
+		fixture, ok := s.match(operations[0])
+		if !ok {
+			return http.StatusNotFound, errorResponse(notFound(operations[0]))
+		}
+
+		return fixture.status(), fixture.Response
+	}
+
+	results := make([]json.RawMessage, 0, len(operations))
+	for _, operation := range operations {
+		fixture, ok := s.match(operation)
+		if !ok {
+			results = append(results, errorResponse(notFound(operation)))
+
+			continue
+		}
+		results = append(results, fixture.Response)
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		return http.StatusInternalServerError, errorResponse(err.Error())
+	}
+
+	return http.StatusOK, body
+}
+
+func (s *Server) match(operation *clientv2.Request) (Fixture, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	variables := normalize(operation.Variables)
+	for _, fixture := range s.fixtures {
+		if fixture.OperationName == operation.OperationName && fixture.Variables != nil && reflect.DeepEqual(normalize(fixture.Variables), variables) {
+			return fixture, true
+		}
+	}
+
+	for _, fixture := range s.fixtures {
+		if fixture.OperationName == operation.OperationName && fixture.Variables == nil {
+			return fixture, true
+		}
+	}
+
+	return Fixture{}, false
+}
+
+// serve writes the response returned by respond for r.
+func serve(w http.ResponseWriter, r *http.Request, respond func(*http.Request) (int, []byte)) {
+	status, body := respond(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// roundTrip returns the response returned by respond for req.
+func roundTrip(req *http.Request, respond func(*http.Request) (int, []byte)) *http.Response {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	status, body := respond(req)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (f Fixture) status() int {
+	if f.Status == 0 {
+		return http.StatusOK
+	}
+
+	return f.Status
+}
+
+func notFound(operation *clientv2.Request) string {
+	variables, _ := json.Marshal(operation.Variables)
+
+	return fmt.Sprintf("no fixture for operation %s with variables %s", operation.OperationName, variables)
+}
+
+func errorResponse(message string) []byte {
+	body, _ := json.Marshal(map[string]any{
+		"errors": []map[string]any{{"message": message}},
+	})
+
+	return body
+}
+
+// normalize returns variables decoded from JSON, so that they compare equal whatever the Go types they were made of.
+func normalize(variables map[string]any) any {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	content, err := json.Marshal(variables)
+	if err != nil {
+		return variables
+	}
+
+	var normalized any
+	if err := json.Unmarshal(content, &normalized); err != nil {
+		return variables
+	}
+
+	return normalized
+}
+
+// readOperations returns the operations of a GraphQL request sent with GET, with a JSON body, which is a list
+// for a batch, or with a multipart body for uploads. The body of r can be read again afterwards.
+func readOperations(r *http.Request) ([]*clientv2.Request, bool, error) {
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		operation := &clientv2.Request{OperationName: query.Get("operationName"), Query: query.Get("query")}
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &operation.Variables); err != nil {
+				return nil, false, fmt.Errorf("decode variables: %w", err)
+			}
+		}
+
+		return []*clientv2.Request{operation}, false, nil
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		clone := r.Clone(r.Context())
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		if err := clone.ParseMultipartForm(32 << 20); err != nil {
+			return nil, false, fmt.Errorf("parse multipart body: %w", err)
+		}
+		body = []byte(clone.FormValue("operations"))
+	}
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var operations []*clientv2.Request
+		if err := json.Unmarshal(trimmed, &operations); err != nil {
+			return nil, false, fmt.Errorf("decode batch: %w", err)
+		}
+
+		return operations, true, nil
+	}
+
+	var operation clientv2.Request
+	if err := json.Unmarshal(body, &operation); err != nil {
+		return nil, false, fmt.Errorf("decode request: %w", err)
+	}
+
+	return []*clientv2.Request{&operation}, false, nil
+}
+
+// readBody reads the body of r and replaces it with a copy, so that it can be sent afterwards.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,124 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+)
+
+const getUserQuery = "query GetUser($id: ID!) { user(id: $id) { id login } }"
+
+type getUserRes struct {
+	User *struct {
+		ID    string `json:"id"`
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	s, err := LoadServer("testdata/fixtures.yaml")
+	require.NoError(t, err)
+
+	t.Run("matches variables", func(t *testing.T) {
+		t.Parallel()
+		c := clientv2.NewClient(s.Client(), "http://fixture/graphql", nil)
+
+		var res getUserRes
+		require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "1"}))
+		require.Equal(t, "octocat", res.User.Login)
+	})
+
+	t.Run("falls back to the fixture without variables", func(t *testing.T) {
+		t.Parallel()
+		c := clientv2.NewClient(s.Client(), "http://fixture/graphql", nil)
+
+		var res getUserRes
+		err := c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "2"})
+		require.Len(t, clientv2.GraphQLErrors(err), 1)
+		require.Equal(t, "user not found", clientv2.GraphQLErrors(err)[0].Message)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		t.Parallel()
+		c := clientv2.NewClient(s.Client(), "http://fixture/graphql", nil)
+
+		err := c.Post(context.Background(), "Unavailable", "query Unavailable { name }", &struct{}{}, nil)
+		var httpErr *clientv2.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		t.Parallel()
+		c := clientv2.NewClient(s.Client(), "http://fixture/graphql", nil)
+
+		err := c.Post(context.Background(), "Unknown", "query Unknown { name }", &struct{}{}, nil)
+		require.Contains(t, clientv2.GraphQLErrors(err)[0].Message, "no fixture for operation Unknown")
+	})
+
+	t.Run("http server with GET and batches", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(s)
+		t.Cleanup(srv.Close)
+		c := clientv2.NewClient(http.DefaultClient, srv.URL, &clientv2.Options{RequestMode: clientv2.RequestModeGET})
+
+		var res getUserRes
+		require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "1"}))
+		require.Equal(t, "octocat", res.User.Login)
+
+		var first, second getUserRes
+		batch := []*clientv2.BatchRequest{
+			{OperationName: "GetUser", Query: getUserQuery, Variables: map[string]any{"id": "1"}, RespData: &first},
+			{OperationName: "GetUser", Query: getUserQuery, Variables: map[string]any{"id": "3"}, RespData: &second},
+		}
+		require.NoError(t, c.PostBatch(context.Background(), batch))
+		require.NoError(t, batch[0].Err)
+		require.Equal(t, "octocat", first.User.Login)
+		require.Error(t, batch[1].Err)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req clientv2.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"user":{"id":"` + req.Variables["id"].(string) + `","login":"recorded"}}}`))
+	}))
+	t.Cleanup(upstream.Close)
+
+	recorder := NewRecorder(nil)
+	c := clientv2.NewClient(recorder.Client(), upstream.URL, nil)
+
+	var res getUserRes
+	require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "1"}))
+	require.Equal(t, "recorded", res.User.Login)
+	require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "1"}))
+	require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "2"}))
+	require.Len(t, recorder.Fixtures(), 2)
+
+	for _, name := range []string{"fixtures.json", "fixtures.yml"} {
+		filename := filepath.Join(t.TempDir(), name)
+		require.NoError(t, recorder.Save(filename))
+
+		s, err := LoadServer(filename)
+		require.NoError(t, err)
+		c := clientv2.NewClient(s.Client(), "http://fixture/graphql", nil)
+
+		res = getUserRes{}
+		require.NoError(t, c.Post(context.Background(), "GetUser", getUserQuery, &res, map[string]any{"id": "2"}))
+		require.Equal(t, "2", res.User.ID)
+		require.Equal(t, "recorded", res.User.Login)
+	}
+}
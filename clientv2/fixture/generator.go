@@ -0,0 +1,330 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const DefaultMaxListLength = 3
+
+// GeneratorOptions configures the Generator returned by NewGenerator.
+type GeneratorOptions struct {
+	// Rand is the source of the generated values, use a seeded one for reproducible data. A random one is used when nil.
+	Rand *rand.Rand
+
+	// Scalars generate the values of custom scalars by name, such as "DateTime".
+	// Custom scalars without a generator get a random string.
+	Scalars map[string]func(r *rand.Rand) any
+
+	// NullProbability is the probability for a nullable field to be null, 0 by default.
+	NullProbability float64
+
+	// MaxListLength is the maximum length of the generated lists, DefaultMaxListLength by default.
+	MaxListLength int
+}
+
+// Generator generates random response data satisfying the selections of operations against a schema:
+// non-null fields are never null, enums get one of their values, and abstract types get one of their
+// possible types along with its __typename.
+// It is an http.Handler and an http.RoundTripper like Server, answering every operation with generated data.
+type Generator struct {
+	schema  *ast.Schema
+	options GeneratorOptions
+
+	mu sync.Mutex
+}
+
+// NewGenerator returns a Generator of the responses of operations on schema.
+func NewGenerator(schema *ast.Schema, options GeneratorOptions) *Generator {
+	if options.Rand == nil {
+		options.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	if options.MaxListLength <= 0 {
+		options.MaxListLength = DefaultMaxListLength
+	}
+
+	return &Generator{schema: schema, options: options}
+}
+
+// Generate returns the data of a response to the operation named operationName in document, which can be empty
+// when the document has a single operation. Variables are used by @skip and @include.
+func (g *Generator) Generate(document, operationName string, variables map[string]any) (json.RawMessage, error) {
+	queryDocument, errs := gqlparser.LoadQuery(g.schema, document)
+	if errs != nil {
+		return nil, fmt.Errorf("invalid document: %w", errs)
+	}
+
+	operation := queryDocument.Operations.ForName(operationName)
+	if operation == nil {
+		return nil, fmt.Errorf("operation %s not found in document", operationName)
+	}
+
+	var root *ast.Definition
+	switch operation.Operation {
+	case ast.Query:
+		root = g.schema.Query
+	case ast.Mutation:
+		root = g.schema.Mutation
+	case ast.Subscription:
+		root = g.schema.Subscription
+	}
+	if root == nil {
+		return nil, fmt.Errorf("schema has no %s type", operation.Operation)
+	}
+
+	g.mu.Lock()
+	w := &generatorWalker{Generator: g, vars: variables}
+	data := w.object(root, operation.SelectionSet)
+	g.mu.Unlock()
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode data: %w", err)
+	}
+
+	return content, nil
+}
+
+// Client returns an HTTP client whose requests are answered by g, for clientv2.NewClient.
+func (g *Generator) Client() *http.Client {
+	return &http.Client{Transport: g}
+}
+
+func (g *Generator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, g.respond)
+}
+
+// RoundTrip answers req without sending it.
+func (g *Generator) RoundTrip(req *http.Request) (*http.Response, error) {
+	return roundTrip(req, g.respond), nil
+}
+
+// respond returns the status and body of a response with generated data to r, a JSON array for a batch.
+func (g *Generator) respond(r *http.Request) (int, []byte) {
+	operations, batch, err := readOperations(r)
+	if err != nil {
+		return http.StatusBadRequest, errorResponse(err.Error())
+	}
+
+	results := make([]json.RawMessage, 0, len(operations))
+	for _, operation := range operations {
+		data, err := g.Generate(operation.Query, operation.OperationName, operation.Variables)
+		if err != nil {
+			results = append(results, errorResponse(err.Error()))
+
+			continue
+		}
+
+		result, err := json.Marshal(map[string]json.RawMessage{"data": data})
+		if err != nil {
+			return http.StatusInternalServerError, errorResponse(err.Error())
+		}
+		results = append(results, result)
+	}
+
+	if !batch {
+		return http.StatusOK, results[0]
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		return http.StatusInternalServerError, errorResponse(err.Error())
+	}
+
+	return http.StatusOK, body
+}
+
+// generatorWalker generates the data of an operation, with the variables of its request.
+type generatorWalker struct {
+	*Generator
+
+	vars map[string]any
+}
+
+func (w *generatorWalker) object(definition *ast.Definition, selectionSet ast.SelectionSet) map[string]any {
+	object := make(map[string]any)
+	for _, group := range w.collectFields(definition, selectionSet, nil) {
+		field := group.fields[0]
+		if field.Name == "__typename" {
+			object[group.key] = definition.Name
+
+			continue
+		}
+
+		var selections ast.SelectionSet
+		for _, f := range group.fields {
+			selections = append(selections, f.SelectionSet...)
+		}
+
+		object[group.key] = w.value(field.Definition.Type, selections)
+	}
+
+	return object
+}
+
+func (w *generatorWalker) value(typ *ast.Type, selectionSet ast.SelectionSet) any {
+	if !typ.NonNull && w.options.Rand.Float64() < w.options.NullProbability {
+		return nil
+	}
+
+	if typ.Elem != nil {
+		list := make([]any, 1+w.options.Rand.IntN(w.options.MaxListLength))
+		for i := range list {
+			list[i] = w.value(typ.Elem, selectionSet)
+		}
+
+		return list
+	}
+
+	definition := w.schema.Types[typ.NamedType]
+	if definition == nil {
+		return nil
+	}
+
+	switch definition.Kind {
+	case ast.Scalar:
+		return w.scalar(definition.Name)
+	case ast.Enum:
+		if len(definition.EnumValues) == 0 {
+			return nil
+		}
+
+		return definition.EnumValues[w.options.Rand.IntN(len(definition.EnumValues))].Name
+	case ast.Object:
+		return w.object(definition, selectionSet)
+	case ast.Interface, ast.Union:
+		possibleTypes := w.schema.GetPossibleTypes(definition)
+		if len(possibleTypes) == 0 {
+			return nil
+		}
+
+		return w.object(possibleTypes[w.options.Rand.IntN(len(possibleTypes))], selectionSet)
+	}
+
+	return nil
+}
+
+func (w *generatorWalker) scalar(name string) any {
+	r := w.options.Rand
+	if generate, ok := w.options.Scalars[name]; ok {
+		return generate(r)
+	}
+
+	switch name {
+	case "Int":
+		return r.IntN(1000)
+	case "Float":
+		return float64(r.IntN(100000)) / 100
+	case "Boolean":
+		return r.IntN(2) == 1
+	case "ID":
+		return strconv.Itoa(1 + r.IntN(100000))
+	default:
+		return fmt.Sprintf("%s-%d", name, r.IntN(100000))
+	}
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*ast.Field
+}
+
+// collectFields groups the fields of selectionSet that apply to definition by response key, in the order of the document.
+func (w *generatorWalker) collectFields(definition *ast.Definition, selectionSet ast.SelectionSet, groups []*fieldGroup) []*fieldGroup {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !w.included(selection.Directives) {
+				continue
+			}
+
+			key := selection.Alias
+			if key == "" {
+				key = selection.Name
+			}
+
+			found := false
+			for _, group := range groups {
+				if group.key == key {
+					group.fields = append(group.fields, selection)
+					found = true
+
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*ast.Field{selection}})
+			}
+		case *ast.InlineFragment:
+			if w.included(selection.Directives) && w.applies(definition, selection.TypeCondition) {
+				groups = w.collectFields(definition, selection.SelectionSet, groups)
+			}
+		case *ast.FragmentSpread:
+			if selection.Definition != nil && w.included(selection.Directives) && w.applies(definition, selection.Definition.TypeCondition) {
+				groups = w.collectFields(definition, selection.Definition.SelectionSet, groups)
+			}
+		}
+	}
+
+	return groups
+}
+
+// applies reports whether a fragment on typeCondition applies to objects of definition.
+func (w *generatorWalker) applies(definition *ast.Definition, typeCondition string) bool {
+	if typeCondition == "" || typeCondition == definition.Name {
+		return true
+	}
+
+	condition := w.schema.Types[typeCondition]
+	if condition == nil {
+		return false
+	}
+
+	for _, possibleType := range w.schema.GetPossibleTypes(condition) {
+		if possibleType.Name == definition.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// included reports whether the @skip and @include directives keep a selection.
+func (w *generatorWalker) included(directives ast.DirectiveList) bool {
+	condition := func(name string) (bool, bool) {
+		directive := directives.ForName(name)
+		if directive == nil {
+			return false, false
+		}
+
+		arg := directive.Arguments.ForName("if")
+		if arg == nil {
+			return false, false
+		}
+
+		value, err := arg.Value.Value(w.vars)
+		if err != nil {
+			return false, false
+		}
+
+		b, ok := value.(bool)
+
+		return b, ok
+	}
+
+	if skip, ok := condition("skip"); ok && skip {
+		return false
+	}
+	if include, ok := condition("include"); ok && !include {
+		return false
+	}
+
+	return true
+}
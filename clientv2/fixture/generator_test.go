@@ -0,0 +1,147 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func loadTestSchema(t *testing.T) *ast.Schema {
+	t.Helper()
+
+	content, err := os.ReadFile("testdata/schema.graphql")
+	require.NoError(t, err)
+
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: string(content)})
+	require.NoError(t, gqlErr)
+
+	return schema
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	schema := loadTestSchema(t)
+	const query = `query Viewer($withBio: Boolean!) {
+	viewer {
+		__typename
+		id
+		login
+		role
+		bio @include(if: $withBio)
+		createdAt
+		repositories { name stars }
+	}
+	search(text: "go") {
+		__typename
+		... on User { login }
+		...RepositoryFields
+	}
+}
+fragment RepositoryFields on Repository { name }`
+
+	newGenerator := func(nullProbability float64) *Generator {
+		return NewGenerator(schema, GeneratorOptions{
+			Rand:            rand.New(rand.NewPCG(1, 2)),
+			Scalars:         map[string]func(*rand.Rand) any{"DateTime": func(*rand.Rand) any { return "2024-01-01T00:00:00Z" }},
+			NullProbability: nullProbability,
+			MaxListLength:   5,
+		})
+	}
+
+	data, err := newGenerator(0).Generate(query, "Viewer", map[string]any{"withBio": true})
+	require.NoError(t, err)
+
+	var res struct {
+		Viewer struct {
+			Typename     string           `json:"__typename"`
+			ID           string           `json:"id"`
+			Login        string           `json:"login"`
+			Role         string           `json:"role"`
+			Bio          *string          `json:"bio"`
+			CreatedAt    string           `json:"createdAt"`
+			Repositories []map[string]any `json:"repositories"`
+		} `json:"viewer"`
+		Search []map[string]any `json:"search"`
+	}
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, "User", res.Viewer.Typename)
+	require.NotEmpty(t, res.Viewer.ID)
+	require.NotEmpty(t, res.Viewer.Login)
+	require.Contains(t, []string{"ADMIN", "MEMBER"}, res.Viewer.Role)
+	require.NotNil(t, res.Viewer.Bio)
+	require.Equal(t, "2024-01-01T00:00:00Z", res.Viewer.CreatedAt)
+	require.NotEmpty(t, res.Viewer.Repositories)
+	require.LessOrEqual(t, len(res.Viewer.Repositories), 5)
+	for _, repository := range res.Viewer.Repositories {
+		require.ElementsMatch(t, []string{"name", "stars"}, keys(repository))
+	}
+	for _, result := range res.Search {
+		switch result["__typename"] {
+		case "User":
+			require.ElementsMatch(t, []string{"__typename", "login"}, keys(result))
+		case "Repository":
+			require.ElementsMatch(t, []string{"__typename", "name"}, keys(result))
+		default:
+			t.Fatalf("unexpected type %v", result["__typename"])
+		}
+	}
+
+	again, err := newGenerator(0).Generate(query, "Viewer", map[string]any{"withBio": true})
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(again), "a seeded generator generates the same data")
+
+	data, err = newGenerator(1).Generate(query, "Viewer", map[string]any{"withBio": false})
+	require.NoError(t, err)
+	var skipped struct {
+		Viewer map[string]any `json:"viewer"`
+	}
+	require.NoError(t, json.Unmarshal(data, &skipped))
+	require.NotContains(t, skipped.Viewer, "bio", "skipped fields are not generated")
+
+	data, err = newGenerator(1).Generate(`query Node { node(id: "1") { id } viewer { bio } }`, "", nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"node":null,"viewer":{"bio":null}}`, string(data), "nullable fields are null with a probability of 1")
+
+	_, err = newGenerator(0).Generate(`query Unknown { unknown }`, "", nil)
+	require.Error(t, err)
+}
+
+func TestGenerator_Client(t *testing.T) {
+	t.Parallel()
+
+	g := NewGenerator(loadTestSchema(t), GeneratorOptions{})
+
+	var res struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	c := clientv2.NewClient(g.Client(), "http://fixture/graphql", nil)
+	require.NoError(t, c.Post(context.Background(), "Viewer", "query Viewer { viewer { login } }", &res, nil))
+	require.NotEmpty(t, res.Viewer.Login)
+
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+	c = clientv2.NewClient(http.DefaultClient, srv.URL, nil)
+	err := c.Post(context.Background(), "Viewer", "query Viewer { unknown }", &res, nil)
+	require.Len(t, clientv2.GraphQLErrors(err), 1)
+}
+
+func keys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
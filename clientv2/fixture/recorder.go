@@ -0,0 +1,118 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// Recorder sends requests to a real server and records their responses as fixtures, to be saved with Save
+// and served by Server afterwards. A later response replaces the fixture of the same operation and variables,
+// and responses that are not JSON are not recorded.
+type Recorder struct {
+	// Transport sends the requests, http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	fixtures []Fixture
+}
+
+// NewRecorder returns a Recorder sending requests with transport.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	return &Recorder{Transport: transport}
+}
+
+// Client returns an HTTP client whose requests are recorded by r, for clientv2.NewClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip sends req and records its response.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	operations, batch, err := readOperations(req)
+	if err != nil {
+		return nil, fmt.Errorf("fixture recorder: %w", err)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("fixture recorder: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !json.Valid(body) {
+		return resp, nil
+	}
+
+	if !batch {
+		r.record(Fixture{
+			OperationName: operations[0].OperationName,
+			Variables:     operations[0].Variables,
+			Status:        resp.StatusCode,
+			Response:      body,
+		})
+
+		return resp, nil
+	}
+
+	var results []json.RawMessage
+	if json.Unmarshal(body, &results) == nil && len(results) == len(operations) {
+		for i, operation := range operations {
+			r.record(Fixture{
+				OperationName: operation.OperationName,
+				Variables:     operation.Variables,
+				Response:      results[i],
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) record(fixture Fixture) {
+	if fixture.Status == http.StatusOK {
+		fixture.Status = 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	variables := normalize(fixture.Variables)
+	for i, recorded := range r.fixtures {
+		if recorded.OperationName == fixture.OperationName && reflect.DeepEqual(normalize(recorded.Variables), variables) {
+			r.fixtures[i] = fixture
+
+			return
+		}
+	}
+
+	r.fixtures = append(r.fixtures, fixture)
+}
+
+// Fixtures returns the recorded fixtures, in the order of their first request.
+func (r *Recorder) Fixtures() []Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Fixture(nil), r.fixtures...)
+}
+
+// Save writes the recorded fixtures to a JSON or YAML file.
+func (r *Recorder) Save(filename string) error {
+	return Save(filename, r.Fixtures())
+}
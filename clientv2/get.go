@@ -0,0 +1,98 @@
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// RequestMode selects the HTTP method used to send queries.
+type RequestMode int
+
+const (
+	// RequestModePOST sends every operation as a POST request with a JSON body.
+	RequestModePOST RequestMode = iota
+	// RequestModeGET sends queries as GET requests with the operation in the URL, so that they can be cached by CDNs.
+	// Mutations, requests with uploaded files, and queries whose URL is longer than MaxGETURLLength are sent as POST requests.
+	RequestModeGET
+)
+
+// DefaultMaxGETURLLength is the longest URL sent as a GET request when Client.MaxGETURLLength is not set.
+const DefaultMaxGETURLLength = 2048
+
+type requestModeKey struct{}
+
+// WithRequestMode overrides Client.RequestMode for the requests made with the returned context.
+func WithRequestMode(ctx context.Context, mode RequestMode) context.Context {
+	return context.WithValue(ctx, requestModeKey{}, mode)
+}
+
+func (c *Client) requestMode(ctx context.Context) RequestMode {
+	if mode, ok := ctx.Value(requestModeKey{}).(RequestMode); ok {
+		return mode
+	}
+
+	return c.RequestMode
+}
+
+// postOrGet sends r as a GET request when useGET is set and r is a query whose URL is short enough,
+// and as a POST request otherwise.
+func (c *Client) postOrGet(ctx context.Context, r *Request, useGET bool, respData any, interceptors []RequestInterceptor) error {
+	if useGET && !hasUploads(r.Variables) && r.Operation.IsQuery() {
+		gqlInfo, req, err := c.newGetRequest(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		maxURLLength := c.MaxGETURLLength
+		if maxURLLength <= 0 {
+			maxURLLength = DefaultMaxGETURLLength
+		}
+
+		if len(req.URL.String()) <= maxURLLength {
+			return c.send(ctx, req, gqlInfo, respData, interceptors)
+		}
+	}
+
+	return c.post(ctx, r, respData, interceptors)
+}
+
+// newGetRequest builds a GET request carrying the operation in the query string https://graphql.github.io/graphql-over-http/draft/#sec-GET
+func (c *Client) newGetRequest(ctx context.Context, r *Request) (*GQLRequestInfo, *http.Request, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse base url: %w", err)
+	}
+
+	params := u.Query()
+	if r.Query != "" {
+		params.Set("query", r.Query)
+	}
+	if r.OperationName != "" {
+		params.Set("operationName", r.OperationName)
+	}
+	if len(r.Variables) > 0 {
+		variables, err := MarshalJSON(ctx, r.Variables)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encode variables: %w", err)
+		}
+		params.Set("variables", string(variables))
+	}
+	if len(r.Extensions) > 0 {
+		extensions, err := MarshalJSON(ctx, r.Extensions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encode extensions: %w", err)
+		}
+		params.Set("extensions", string(extensions))
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request struct failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+
+	return NewGQLRequestInfo(r), req, nil
+}
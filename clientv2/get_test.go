@@ -0,0 +1,69 @@
+package clientv2
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPost_requestMode(t *testing.T) {
+	t.Parallel()
+
+	const query = "query Find($id: Int!) { find(id: $id) }"
+
+	t.Run("queries are sent as GET requests", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newGraphQLHandler(transport.GET{}, transport.POST{}))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{RequestMode: RequestModeGET})
+
+		var res nameRes
+		require.NoError(t, c.Post(context.Background(), "Find", query, &res, map[string]any{"id": 1}))
+		require.Equal(t, "test", res.Name)
+
+		req := srv.requests()[0]
+		require.Equal(t, http.MethodGet, req.method)
+		require.Equal(t, url.Values{
+			"query":         {query},
+			"operationName": {"Find"},
+			"variables":     {`{"id":1}`},
+		}, req.url.Query())
+	})
+
+	t.Run("mutations are sent as POST requests", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newGraphQLHandler(transport.GET{}, transport.POST{}))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{RequestMode: RequestModeGET})
+
+		var res nameRes
+		_ = c.Post(context.Background(), "SetName", "mutation SetName { name }", &res, nil)
+		require.Equal(t, http.MethodPost, srv.requests()[0].method)
+	})
+
+	t.Run("long queries fall back to POST requests", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newGraphQLHandler(transport.GET{}, transport.POST{}))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{RequestMode: RequestModeGET, MaxGETURLLength: len(srv.URL) + 16})
+
+		var res nameRes
+		require.NoError(t, c.Post(context.Background(), "Find", query, &res, map[string]any{"id": 1}))
+		require.Equal(t, http.MethodPost, srv.requests()[0].method)
+	})
+
+	t.Run("request mode can be set per call", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newGraphQLHandler(transport.GET{}, transport.POST{}))
+
+		var res nameRes
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		require.NoError(t, c.Post(WithRequestMode(context.Background(), RequestModeGET), "Find", query, &res, map[string]any{"id": 1}))
+		require.Equal(t, http.MethodGet, srv.requests()[0].method)
+
+		c = NewClient(http.DefaultClient, srv.URL, &Options{RequestMode: RequestModeGET})
+		require.NoError(t, c.Post(WithRequestMode(context.Background(), RequestModePOST), "Find", query, &res, map[string]any{"id": 1}))
+		require.Equal(t, http.MethodPost, srv.requests()[1].method)
+	})
+}
@@ -0,0 +1,446 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Yamashou/gqlgenc/graphqljson"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// incremental delivery over multipart HTTP https://github.com/graphql/graphql-over-http/blob/main/rfcs/IncrementalDelivery.md
+const incrementalAccept = "multipart/mixed; deferSpec=20220824, application/json"
+
+// DeferredFragment is embedded in the generated struct of a fragment marked with @defer.
+type DeferredFragment struct {
+	arrived bool
+}
+
+// Arrived reports whether the data of the deferred fragment has been delivered.
+func (d DeferredFragment) Arrived() bool {
+	return d.arrived
+}
+
+func (d *DeferredFragment) markArrived() {
+	d.arrived = true
+}
+
+type deferredFragment interface {
+	markArrived()
+}
+
+var deferredFragmentType = reflect.TypeFor[deferredFragment]()
+
+type incrementalPayload struct {
+	Incremental []incrementalResult `json:"incremental"`
+	HasNext     *bool               `json:"hasNext"`
+	Errors      gqlerror.List       `json:"errors"`
+}
+
+type incrementalResult struct {
+	Data   json.RawMessage `json:"data"`
+	Items  json.RawMessage `json:"items"`
+	Path   ast.Path        `json:"path"`
+	Label  string          `json:"label"`
+	Errors gqlerror.List   `json:"errors"`
+}
+
+// PostIncremental sends an operation using @defer or @stream.
+// The initial result and the later ones are read from the returned Stream.
+// A server that does not support incremental delivery may answer with a single result,
+// which is returned as the only result of the stream.
+func (c *Client) PostIncremental(ctx context.Context, operationName, query string, vars map[string]any, interceptors ...RequestInterceptor) (*Stream, error) {
+	return c.PostIncrementalOperation(ctx, c.describeOperation(operationName, query), vars, interceptors...)
+}
+
+// PostIncrementalOperation is PostIncremental for callers that describe the operation, as generated clients do.
+func (c *Client) PostIncrementalOperation(ctx context.Context, operation *Operation, vars map[string]any, interceptors ...RequestInterceptor) (*Stream, error) {
+	stream := newStream(ctx, c, operation)
+	stream.incremental = true
+
+	r := &Request{
+		Query:         operation.Document,
+		Variables:     vars,
+		OperationName: operation.Name,
+		Operation:     operation,
+	}
+	gqlInfo, req, err := c.newRequest(ctx, r, incrementalAccept)
+	if err != nil {
+		return nil, err
+	}
+
+	f := c.chainInterceptors(interceptors)
+	if err := f(ctx, req, gqlInfo, stream, stream.startMultipart); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (s *Stream) startMultipart(_ context.Context, req *http.Request, _ *GQLRequestInfo, _ any) error {
+	resp, err := s.client.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", &TransportError{Err: err})
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		return s.startSingle(resp)
+	}
+
+	s.closeTransport = resp.Body.Close
+	go s.readMultipart(resp, params["boundary"])
+
+	return nil
+}
+
+func (s *Stream) readMultipart(resp *http.Response, boundary string) {
+	defer close(s.messages)
+	defer resp.Body.Close()
+
+	reader := multipart.NewReader(resp.Body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			// the last boundary ends the stream with io.EOF
+			if !errors.Is(err, io.EOF) {
+				s.fail(err)
+			}
+
+			return
+		}
+
+		var payload json.RawMessage
+		err = json.NewDecoder(part).Decode(&payload)
+		part.Close()
+		if err != nil {
+			// servers may send empty parts to keep the connection alive
+			if errors.Is(err, io.EOF) {
+				continue
+			}
+			s.fail(err)
+
+			return
+		}
+		if string(payload) == "{}" {
+			// heartbeat
+			continue
+		}
+
+		if !s.deliver(streamMessage{Type: streamNext, Payload: payload}) {
+			return
+		}
+	}
+}
+
+// nextIncremental decodes the initial result into respData, then merges the later results into it.
+func (s *Stream) nextIncremental(body []byte, respData any) error {
+	var payload incrementalPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Errorf("failed to decode data %s: %w", string(body), &DecodeError{Body: body, Err: err})
+	}
+
+	if payload.HasNext != nil && !*payload.HasNext {
+		s.finished = true
+	}
+
+	if !s.received {
+		s.received = true
+
+		err := s.client.parseResponse(body, http.StatusOK, respData)
+		if payload.HasNext == nil || !*payload.HasNext {
+			// the server ignored @defer, so every fragment is already there
+			markAllDeferred(reflect.ValueOf(respData))
+		}
+
+		return checkSemanticNonNull(s.operation, body, err)
+	}
+
+	errs := payload.Errors
+	var nullPaths []ast.Path
+	for _, result := range payload.Incremental {
+		errs = append(errs, result.Errors...)
+		if len(result.Errors) > 0 {
+			nullPaths = append(nullPaths, semanticNullPaths(s.operation, result)...)
+		}
+
+		var err error
+		if result.Items != nil {
+			err = applyItems(respData, result)
+		} else {
+			err = applyData(respData, result)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(nullPaths) > 0 {
+		return &SemanticNullError{Paths: nullPaths, Err: &ErrorResponse{GqlErrors: &errs}}
+	}
+	if len(errs) > 0 {
+		return &ErrorResponse{GqlErrors: &errs}
+	}
+
+	return nil
+}
+
+// semanticNullPaths returns the paths of the semantically non-null fields of operation made null by the errors
+// of result, checked like the ones of a complete response whose data is the one of result at its path.
+func semanticNullPaths(operation *Operation, result incrementalResult) []ast.Path {
+	if operation == nil || len(operation.SemanticNonNull) == 0 {
+		return nil
+	}
+
+	var data any
+	path := result.Path
+	if result.Items != nil {
+		if len(path) == 0 {
+			return nil
+		}
+		index, ok := path[len(path)-1].(ast.PathIndex)
+		if !ok {
+			return nil
+		}
+		path = path[:len(path)-1]
+
+		var items []any
+		if json.Unmarshal(result.Items, &items) != nil {
+			return nil
+		}
+		// the items before the first one of the result are not part of it
+		data = append(make([]any, index), items...)
+	} else if json.Unmarshal(result.Data, &data) != nil {
+		return nil
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		switch element := path[i].(type) {
+		case ast.PathName:
+			data = map[string]any{string(element): data}
+		case ast.PathIndex:
+			list := make([]any, element+1)
+			list[element] = data
+			data = list
+		}
+	}
+
+	body, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return nil
+	}
+
+	var semanticNullErr *SemanticNullError
+	if errors.As(checkSemanticNonNull(operation, body, &ErrorResponse{GqlErrors: &result.Errors}), &semanticNullErr) {
+		return semanticNullErr.Paths
+	}
+
+	return nil
+}
+
+// applyData merges the data of a deferred fragment into the object at its path.
+func applyData(respData any, result incrementalResult) error {
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		return nil
+	}
+
+	v, ok := graphqljson.ValueAtPath(respData, result.Path)
+	if !ok {
+		return fmt.Errorf("incremental result path %s not found in response", result.Path)
+	}
+
+	target := v
+	if v.CanAddr() {
+		target = v.Addr()
+	}
+
+	if err := graphqljson.UnmarshalData(result.Data, target.Interface()); err != nil {
+		return fmt.Errorf("failed to decode incremental data %s: %w", string(result.Data), &DecodeError{Body: result.Data, Err: err})
+	}
+
+	var keys map[string]json.RawMessage
+	if result.Label == "" {
+		// unlabeled fragments are told apart by the fields of the result
+		if err := json.Unmarshal(result.Data, &keys); err != nil {
+			return fmt.Errorf("failed to decode incremental data %s: %w", string(result.Data), &DecodeError{Body: result.Data, Err: err})
+		}
+	}
+	markDeferred(v, result.Label, keys)
+
+	return nil
+}
+
+// applyItems sets the items of a streamed list, whose path ends with the index of the first item.
+func applyItems(respData any, result incrementalResult) error {
+	if len(result.Path) == 0 {
+		return fmt.Errorf("incremental result without path")
+	}
+
+	index, ok := result.Path[len(result.Path)-1].(ast.PathIndex)
+	if !ok {
+		return fmt.Errorf("incremental result path %s does not end with an index", result.Path)
+	}
+
+	list, ok := graphqljson.ValueAtPath(respData, result.Path[:len(result.Path)-1])
+	if !ok {
+		return fmt.Errorf("incremental result path %s not found in response", result.Path)
+	}
+	for list.Kind() == reflect.Ptr {
+		if list.IsNil() {
+			list.Set(reflect.New(list.Type().Elem()))
+		}
+		list = list.Elem()
+	}
+	if list.Kind() != reflect.Slice {
+		return fmt.Errorf("incremental result path %s is not a list", result.Path)
+	}
+
+	items := reflect.New(list.Type())
+	if err := graphqljson.UnmarshalData(result.Items, items.Interface()); err != nil {
+		return fmt.Errorf("failed to decode incremental items %s: %w", string(result.Items), &DecodeError{Body: result.Items, Err: err})
+	}
+
+	for i := range items.Elem().Len() {
+		item := items.Elem().Index(i)
+		if pos := int(index) + i; pos < list.Len() {
+			list.Index(pos).Set(item)
+		} else {
+			list.Set(reflect.Append(list, item))
+		}
+	}
+
+	return nil
+}
+
+// markDeferred marks the deferred fragments of object v delivered by a result as arrived: the ones with its label,
+// or when it has none, the unlabeled ones whose fields are all in keys, the fields of the result.
+// Unlabeled fragments have a defer tag holding their position, such as ",1".
+func markDeferred(v reflect.Value, label string, keys map[string]json.RawMessage) {
+	v = indirectValue(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range v.NumField() {
+		sf := v.Type().Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if l, ok := sf.Tag.Lookup("defer"); ok {
+			if (label != "" && l == label) || (label == "" && strings.HasPrefix(l, ",") && hasFields(sf.Type, keys)) {
+				markArrived(v.Field(i))
+			}
+
+			continue
+		}
+
+		if sf.Anonymous || strings.HasPrefix(strings.TrimSpace(sf.Tag.Get("graphql")), "...") {
+			markDeferred(v.Field(i), label, keys)
+		}
+	}
+}
+
+// hasFields reports whether keys has every field of struct type t, except the ones of its deferred fragments.
+func hasFields(t reflect.Type, keys map[string]json.RawMessage) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if sf.PkgPath != "" || sf.Type == reflect.TypeFor[DeferredFragment]() {
+			continue
+		}
+		if _, ok := sf.Tag.Lookup("defer"); ok {
+			continue
+		}
+
+		name, ok := sf.Tag.Lookup("graphql")
+		name = strings.TrimSpace(name)
+		if sf.Anonymous || strings.HasPrefix(name, "...") {
+			if !hasFields(sf.Type, keys) {
+				return false
+			}
+
+			continue
+		}
+
+		if !ok {
+			if !slices.ContainsFunc(slices.Collect(maps.Keys(keys)), func(key string) bool { return strings.EqualFold(key, sf.Name) }) {
+				return false
+			}
+
+			continue
+		}
+
+		if i := strings.IndexAny(name, "(:"); i != -1 {
+			name = strings.TrimSpace(name[:i])
+		}
+		if _, ok := keys[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// markAllDeferred marks every deferred fragment reachable from v as arrived.
+func markAllDeferred(v reflect.Value) {
+	v = indirectValue(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			if _, ok := sf.Tag.Lookup("defer"); ok {
+				markArrived(v.Field(i))
+			}
+			markAllDeferred(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			markAllDeferred(v.Index(i))
+		}
+	default:
+	}
+}
+
+func markArrived(v reflect.Value) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+	if v.Type().Implements(deferredFragmentType) {
+		v.Interface().(deferredFragment).markArrived() //nolint:forcetypeassert
+	}
+}
+
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
@@ -0,0 +1,206 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+)
+
+type deferredNameRes struct {
+	NameFragment struct {
+		DeferredFragment
+		Name *string `graphql:"name"`
+	} `graphql:"... @defer" defer:",1"`
+}
+
+type repositoryRes struct {
+	Viewer struct {
+		Login   string `graphql:"login"`
+		Details struct {
+			DeferredFragment
+			Bio string `graphql:"bio"`
+		} `graphql:"... @defer(label: \"details\")" defer:"details"`
+		Repositories []struct {
+			Name string `graphql:"name"`
+		} `graphql:"repositories"`
+	} `graphql:"viewer"`
+}
+
+type unlabeledRes struct {
+	Viewer struct {
+		Deferred struct {
+			DeferredFragment
+			Bio string `graphql:"bio"`
+		} `graphql:"..." defer:",1"`
+		Deferred2 struct {
+			DeferredFragment
+			Company string `graphql:"company"`
+		} `graphql:"..." defer:",2"`
+	} `graphql:"viewer"`
+}
+
+func writeMultipart(w http.ResponseWriter, parts ...string) {
+	w.Header().Set("Content-Type", `multipart/mixed; boundary="-"; deferSpec=20220824`)
+	w.WriteHeader(http.StatusOK)
+	for _, part := range parts {
+		fmt.Fprintf(w, "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n%s", part)
+		w.(http.Flusher).Flush()
+	}
+	fmt.Fprint(w, "\r\n-----\r\n")
+}
+
+func TestPostIncremental(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges deferred results from gqlgen", func(t *testing.T) {
+		t.Parallel()
+		h := testserver.New()
+		h.AddTransport(transport.MultipartMixed{})
+		srv := httptest.NewServer(h)
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		go h.SendNextSubscriptionMessage()
+		stream, err := c.PostIncremental(context.Background(), "Name", "query Name { ... @defer { name } }", nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res deferredNameRes
+		require.NoError(t, stream.Next(&res))
+		require.False(t, res.NameFragment.Arrived())
+		require.Nil(t, res.NameFragment.Name)
+
+		go h.SendNextSubscriptionMessage()
+		require.NoError(t, stream.Next(&res))
+		require.True(t, res.NameFragment.Arrived())
+		require.Equal(t, "test", *res.NameFragment.Name)
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+
+	t.Run("applies labeled fragments and streamed items at their path", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Contains(t, r.Header.Get("Accept"), "multipart/mixed")
+			writeMultipart(w,
+				`{"data":{"viewer":{"login":"octocat","repositories":[{"name":"r0"}]}},"hasNext":true}`,
+				`{"incremental":[{"items":[{"name":"r1"},{"name":"r2"}],"path":["viewer","repositories",1]}],"hasNext":true}`,
+				`{}`,
+				`{"incremental":[{"data":{"bio":"hello"},"path":["viewer"],"label":"details"}],"hasNext":false}`,
+			)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		stream, err := c.PostIncremental(context.Background(), "Viewer", "query Viewer { ... }", nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res repositoryRes
+		require.NoError(t, stream.Next(&res))
+		require.Equal(t, "octocat", res.Viewer.Login)
+		require.Len(t, res.Viewer.Repositories, 1)
+		require.False(t, res.Viewer.Details.Arrived())
+
+		require.NoError(t, stream.Next(&res))
+		require.Len(t, res.Viewer.Repositories, 3)
+		require.Equal(t, "r2", res.Viewer.Repositories[2].Name)
+
+		require.NoError(t, stream.Next(&res))
+		require.True(t, res.Viewer.Details.Arrived())
+		require.Equal(t, "hello", res.Viewer.Details.Bio)
+		require.Equal(t, "octocat", res.Viewer.Login)
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+
+	t.Run("unlabeled fragments are matched by the fields of their results", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeMultipart(w,
+				`{"data":{"viewer":{}},"hasNext":true}`,
+				`{"incremental":[{"data":{"company":"github"},"path":["viewer"]}],"hasNext":true}`,
+				`{"incremental":[{"data":{"bio":"hello"},"path":["viewer"]}],"hasNext":false}`,
+			)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		stream, err := c.PostIncremental(context.Background(), "Viewer", "query Viewer { ... }", nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res unlabeledRes
+		require.NoError(t, stream.Next(&res))
+		require.False(t, res.Viewer.Deferred.Arrived())
+		require.False(t, res.Viewer.Deferred2.Arrived())
+
+		require.NoError(t, stream.Next(&res))
+		require.False(t, res.Viewer.Deferred.Arrived())
+		require.True(t, res.Viewer.Deferred2.Arrived())
+		require.Equal(t, "github", res.Viewer.Deferred2.Company)
+
+		require.NoError(t, stream.Next(&res))
+		require.True(t, res.Viewer.Deferred.Arrived())
+		require.Equal(t, "hello", res.Viewer.Deferred.Bio)
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+
+	t.Run("errors of incremental results are returned", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeMultipart(w,
+				`{"data":{"viewer":{"login":"octocat","repositories":[]}},"hasNext":true}`,
+				`{"incremental":[{"data":null,"path":["viewer"],"label":"details","errors":[{"message":"bio failed","path":["viewer","bio"]}]}],"hasNext":false}`,
+			)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		stream, err := c.PostIncremental(context.Background(), "Viewer", "query Viewer { ... }", nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res repositoryRes
+		require.NoError(t, stream.Next(&res))
+
+		err = stream.Next(&res)
+		var errResponse *ErrorResponse
+		require.True(t, errors.As(err, &errResponse))
+		require.Equal(t, "bio failed", (*errResponse.GqlErrors)[0].Message)
+		require.False(t, res.Viewer.Details.Arrived())
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+
+	t.Run("a single result completes every deferred fragment", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"data":{"viewer":{"login":"octocat","bio":"hello","repositories":[{"name":"r0"}]}}}`)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		stream, err := c.PostIncremental(context.Background(), "Viewer", "query Viewer { ... }", nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res repositoryRes
+		require.NoError(t, stream.Next(&res))
+		require.True(t, res.Viewer.Details.Arrived())
+		require.Equal(t, "hello", res.Viewer.Details.Bio)
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+}
@@ -0,0 +1,215 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the values of redacted variables and headers in logs.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedHeaders are the headers that are always redacted from logs.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// LogOptions configures the interceptor returned by NewLoggingInterceptor.
+type LogOptions struct {
+	// Logger writes the logs. The default is slog.Default().
+	Logger *slog.Logger
+
+	// Level is the level of the logs of successful requests, failed requests are logged at slog.LevelError.
+	Level slog.Level
+
+	// LogVariables adds the variables of the operations to the logs.
+	LogVariables bool
+
+	// LogHeaders adds the headers of the requests to the logs.
+	LogHeaders bool
+
+	// LogResponseBody adds the bodies of the responses to the logs.
+	LogResponseBody bool
+
+	// RedactVariables are the dot separated paths of the variables whose values are replaced by RedactedValue,
+	// such as "input.password". Lists are traversed, so "users.token" redacts the token of every user.
+	RedactVariables []string
+
+	// RedactHeaders are the headers redacted along with DefaultRedactedHeaders.
+	RedactHeaders []string
+}
+
+// NewLoggingInterceptor returns an interceptor that logs the operation name, duration, HTTP status and number of
+// GraphQL errors of each request, and optionally its variables, headers and response body.
+func NewLoggingInterceptor(options LogOptions) RequestInterceptor {
+	redactedHeaders := make(map[string]bool)
+	for _, name := range append(DefaultRedactedHeaders, options.RedactHeaders...) {
+		redactedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+
+	return func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+		logger := options.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+
+		start := time.Now()
+		err := next(ctx, req, gqlInfo, res)
+
+		level := options.Level
+		if err != nil {
+			level = slog.LevelError
+		}
+		if !logger.Enabled(ctx, level) {
+			return err
+		}
+
+		attrs := append(operationLogAttrs(gqlInfo),
+			slog.String("method", req.Method),
+			slog.Duration("duration", time.Since(start)),
+		)
+
+		if gqlInfo.ResponseStatusCode != 0 {
+			attrs = append(attrs, slog.Int("status", gqlInfo.ResponseStatusCode))
+		}
+
+		var errResponse *ErrorResponse
+		if errors.As(err, &errResponse) && errResponse.GqlErrors != nil {
+			attrs = append(attrs, slog.Int("errors", len(*errResponse.GqlErrors)))
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", logError(err, options.LogResponseBody)))
+		}
+
+		if options.LogVariables && gqlInfo.Request != nil && len(gqlInfo.Request.Variables) > 0 {
+			attrs = append(attrs, slog.String("variables", redactVariables(ctx, gqlInfo.Request.Variables, options.RedactVariables)))
+		}
+
+		if options.LogHeaders {
+			attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header, redactedHeaders)))
+		}
+
+		if options.LogResponseBody && gqlInfo.ResponseBody != nil {
+			attrs = append(attrs, slog.String("response", string(gqlInfo.ResponseBody)))
+		}
+
+		logger.LogAttrs(ctx, level, "graphql request", attrs...)
+
+		return err
+	}
+}
+
+// logError returns the message of err for the logs. The messages of non-2xx responses and of decode errors contain
+// the body of the response, so they are replaced by the status code and the decode error unless bodies are logged.
+func logError(err error, logResponseBody bool) string {
+	if logResponseBody {
+		return err.Error()
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		message := fmt.Sprintf("http status %d", httpErr.Code)
+		if gqlErrs := GraphQLErrors(err); len(gqlErrs) > 0 {
+			message += ": " + gqlErrs.Error()
+		}
+
+		return message
+	}
+
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return "failed to decode response: " + decodeErr.Error()
+	}
+
+	return err.Error()
+}
+
+func operationLogAttrs(gqlInfo *GQLRequestInfo) []slog.Attr {
+	if gqlInfo.Batch != nil {
+		names := make([]string, 0, len(gqlInfo.Batch))
+		for _, r := range gqlInfo.Batch {
+			names = append(names, r.OperationName)
+		}
+
+		return []slog.Attr{slog.Any("operations", names)}
+	}
+
+	var attrs []slog.Attr
+	if gqlInfo.Request != nil {
+		attrs = append(attrs, slog.String("operation", gqlInfo.Request.OperationName))
+	}
+	if gqlInfo.Operation != nil && gqlInfo.Operation.Type != "" {
+		attrs = append(attrs, slog.String("type", string(gqlInfo.Operation.Type)))
+	}
+
+	return attrs
+}
+
+// redactVariables returns the variables encoded in JSON, with the values at paths replaced by RedactedValue.
+func redactVariables(ctx context.Context, vars map[string]any, paths []string) string {
+	encoded, err := MarshalJSON(ctx, vars)
+	if err != nil {
+		return RedactedValue
+	}
+
+	if len(paths) == 0 {
+		return string(encoded)
+	}
+
+	var decoded any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return RedactedValue
+	}
+
+	for _, path := range paths {
+		redactPath(decoded, strings.Split(path, "."))
+	}
+
+	redacted, err := json.Marshal(decoded)
+	if err != nil {
+		return RedactedValue
+	}
+
+	return string(redacted)
+}
+
+func redactPath(value any, path []string) {
+	switch value := value.(type) {
+	case map[string]any:
+		child, ok := value[path[0]]
+		if !ok {
+			return
+		}
+
+		if len(path) == 1 {
+			value[path[0]] = RedactedValue
+
+			return
+		}
+
+		redactPath(child, path[1:])
+	case []any:
+		for _, item := range value {
+			redactPath(item, path)
+		}
+	}
+}
+
+func redactHeaders(header http.Header, redacted map[string]bool) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if redacted[http.CanonicalHeaderKey(name)] {
+			headers[name] = RedactedValue
+
+			continue
+		}
+
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
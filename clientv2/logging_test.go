@@ -0,0 +1,147 @@
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLog returns the single record written to buf.
+func decodeLog(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), buf.String())
+
+	return record
+}
+
+func TestNewLoggingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	const query = "query Name($input: Input!) { name(input: $input) }"
+
+	t.Run("logs the operation", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondWithJSON(`{"data":{"name":"test"}}`))
+		var buf bytes.Buffer
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewLoggingInterceptor(LogOptions{
+			Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		}))
+
+		var res struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, map[string]any{"input": map[string]any{"password": "secret"}}))
+
+		record := decodeLog(t, &buf)
+		require.Equal(t, "INFO", record["level"])
+		require.Equal(t, "graphql request", record["msg"])
+		require.Equal(t, "Name", record["operation"])
+		require.Equal(t, "query", record["type"])
+		require.Equal(t, float64(http.StatusOK), record["status"])
+		require.Contains(t, record, "duration")
+		require.NotContains(t, record, "variables")
+		require.NotContains(t, record, "headers")
+		require.NotContains(t, record, "response")
+	})
+
+	t.Run("logs GraphQL errors at error level", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondWithJSON(`{"errors":[{"message":"first"},{"message":"second"}]}`))
+		var buf bytes.Buffer
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewLoggingInterceptor(LogOptions{
+			Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		}))
+
+		require.Error(t, c.Post(context.Background(), "Name", query, &struct{}{}, nil))
+
+		record := decodeLog(t, &buf)
+		require.Equal(t, "ERROR", record["level"])
+		require.Equal(t, float64(2), record["errors"])
+		require.Contains(t, record["error"], "first")
+	})
+
+	t.Run("logs the bodies of failed responses only when enabled", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "session secret", http.StatusBadGateway)
+		}))
+
+		for _, logResponseBody := range []bool{false, true} {
+			var buf bytes.Buffer
+			c := NewClient(http.DefaultClient, srv.URL, nil, NewLoggingInterceptor(LogOptions{
+				Logger:          slog.New(slog.NewJSONHandler(&buf, nil)),
+				LogResponseBody: logResponseBody,
+			}))
+
+			require.Error(t, c.Post(context.Background(), "Name", query, &struct{}{}, nil))
+
+			record := decodeLog(t, &buf)
+			require.Equal(t, float64(http.StatusBadGateway), record["status"])
+			if logResponseBody {
+				require.Contains(t, record["error"], "session secret")
+			} else {
+				require.Equal(t, "http status 502", record["error"])
+				require.NotContains(t, buf.String(), "secret")
+			}
+		}
+	})
+
+	t.Run("redacts variables and headers", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondWithJSON(`{"data":{"name":"test"}}`))
+		var buf bytes.Buffer
+		c := NewClient(http.DefaultClient, srv.URL, nil, func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-Api-Key", "key")
+			req.Header.Set("X-Request-Id", "1")
+
+			return next(ctx, req, gqlInfo, res)
+		}, NewLoggingInterceptor(LogOptions{
+			Logger:          slog.New(slog.NewJSONHandler(&buf, nil)),
+			LogVariables:    true,
+			LogHeaders:      true,
+			LogResponseBody: true,
+			RedactVariables: []string{"input.password", "input.users.token"},
+			RedactHeaders:   []string{"x-api-key"},
+		}))
+
+		vars := map[string]any{"input": map[string]any{
+			"login":    "octocat",
+			"password": "secret",
+			"users":    []any{map[string]any{"token": "a"}, map[string]any{"token": "b"}},
+		}}
+		require.NoError(t, c.Post(context.Background(), "Name", query, &struct {
+			Name string `json:"name"`
+		}{}, vars))
+
+		record := decodeLog(t, &buf)
+		require.JSONEq(t, `{"input":{"login":"octocat","password":"[REDACTED]","users":[{"token":"[REDACTED]"},{"token":"[REDACTED]"}]}}`, record["variables"].(string))
+		headers := record["headers"].(map[string]any)
+		require.Equal(t, RedactedValue, headers["Authorization"])
+		require.Equal(t, RedactedValue, headers["X-Api-Key"])
+		require.Equal(t, "1", headers["X-Request-Id"])
+		require.JSONEq(t, `{"data":{"name":"test"}}`, record["response"].(string))
+	})
+
+	t.Run("skips disabled levels", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondWithJSON(`{"data":{"name":"test"}}`))
+		var buf bytes.Buffer
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewLoggingInterceptor(LogOptions{
+			Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+			Level:  slog.LevelDebug,
+		}))
+
+		require.NoError(t, c.Post(context.Background(), "Name", query, &struct {
+			Name string `json:"name"`
+		}{}, nil))
+		require.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,139 @@
+package clientv2
+
+import (
+	"slices"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Operation describes a GraphQL operation, so that interceptors know what is sent without parsing its document.
+// Generated clients declare one for each operation, they are shared between requests and must not be modified.
+type Operation struct {
+	// Type is query, mutation or subscription. It is empty when the document cannot be parsed.
+	Type ast.Operation
+	// Name is the name of the operation, as sent in the operationName field.
+	Name string
+	// Document is the query sent to the server.
+	Document string
+	// DocumentHash is the hex encoded SHA-256 hash of Document, computed with DocumentHash when empty.
+	DocumentHash string
+	// RootFields are the names of the fields selected on the root type, in the order of the document.
+	RootFields []string
+	// SourceFile is the query file the operation was generated from, empty for operations not made by the generator.
+	SourceFile string
+	// SemanticNonNull are the response paths of the fields generated as non-pointer types for @semanticNonNull,
+	// such as "viewer.name", with "[]" for the items of a list, such as "viewer.repositories[]".
+	SemanticNonNull []string
+}
+
+// IsQuery reports whether the operation is a query, which unlike mutations is safe to send again.
+func (o *Operation) IsQuery() bool {
+	return o != nil && o.Type == ast.Query
+}
+
+// IsMutation reports whether the operation is a mutation.
+func (o *Operation) IsMutation() bool {
+	return o != nil && o.Type == ast.Mutation
+}
+
+func (o *Operation) hash() string {
+	if o.DocumentHash != "" {
+		return o.DocumentHash
+	}
+
+	return DocumentHash(o.Document)
+}
+
+type operationKey struct {
+	query         string
+	operationName string
+}
+
+// operationCacheSize bounds the number of operations a Client keeps described,
+// so that clients posting many distinct queries do not grow without limit.
+const operationCacheSize = 1024
+
+func newOperationCache() *lru.Cache[operationKey, *Operation] {
+	// lru.New only fails for a non-positive size
+	cache, _ := lru.New[operationKey, *Operation](operationCacheSize)
+
+	return cache
+}
+
+// describeOperation returns the Operation of the operation named operationName in query,
+// for the callers of Post that do not pass a generated Operation.
+// The operations are cached by clients made with NewClient, and described again by the others.
+func (c *Client) describeOperation(operationName, query string) *Operation {
+	if c.operations == nil {
+		return describeOperation(operationName, query)
+	}
+
+	key := operationKey{query: query, operationName: operationName}
+	if operation, ok := c.operations.Get(key); ok {
+		return operation
+	}
+
+	operation := describeOperation(operationName, query)
+	c.operations.Add(key, operation)
+
+	return operation
+}
+
+func describeOperation(operationName, query string) *Operation {
+	operation := &Operation{
+		Name:     operationName,
+		Document: query,
+	}
+
+	if doc, err := parser.ParseQuery(&ast.Source{Input: query}); err == nil {
+		var definition *ast.OperationDefinition
+		if operationName == "" && len(doc.Operations) == 1 {
+			definition = doc.Operations[0]
+		} else {
+			definition = doc.Operations.ForName(operationName)
+		}
+
+		if definition != nil {
+			operation.Type = definition.Operation
+			operation.RootFields = RootFields(doc, definition.SelectionSet)
+		}
+	}
+
+	return operation
+}
+
+// RootFields returns the names of the fields of selectionSet, including the ones of its fragments,
+// in the order of the document and without duplicates.
+func RootFields(doc *ast.QueryDocument, selectionSet ast.SelectionSet) []string {
+	var fields []string
+	var collect func(selectionSet ast.SelectionSet, spreads []string)
+	collect = func(selectionSet ast.SelectionSet, spreads []string) {
+		for _, selection := range selectionSet {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if !slices.Contains(fields, selection.Name) {
+					fields = append(fields, selection.Name)
+				}
+			case *ast.InlineFragment:
+				collect(selection.SelectionSet, spreads)
+			case *ast.FragmentSpread:
+				if slices.Contains(spreads, selection.Name) {
+					continue
+				}
+
+				fragment := selection.Definition
+				if fragment == nil && doc != nil {
+					fragment = doc.Fragments.ForName(selection.Name)
+				}
+				if fragment != nil {
+					collect(fragment.SelectionSet, append(spreads, selection.Name))
+				}
+			}
+		}
+	}
+	collect(selectionSet, nil)
+
+	return fields
+}
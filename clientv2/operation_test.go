@@ -0,0 +1,110 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestDescribeOperation(t *testing.T) {
+	t.Parallel()
+
+	const query = `query Name { name ...Found ... on Query { find(id: 1) } }
+mutation SetName { name }
+fragment Found on Query { name found: find(id: 2) ...Found }`
+
+	c := NewClient(http.DefaultClient, "", nil)
+
+	operation := c.describeOperation("Name", query)
+	require.Equal(t, &Operation{
+		Type:       ast.Query,
+		Name:       "Name",
+		Document:   query,
+		RootFields: []string{"name", "find"},
+	}, operation)
+	require.Same(t, operation, c.describeOperation("Name", query))
+	require.NotSame(t, operation, NewClient(http.DefaultClient, "", nil).describeOperation("Name", query))
+	require.NotSame(t, operation, (&Client{}).describeOperation("Name", query))
+	require.True(t, operation.IsQuery())
+
+	require.True(t, c.describeOperation("SetName", query).IsMutation())
+	require.Equal(t, ast.Operation(""), c.describeOperation("Other", query).Type)
+	require.Equal(t, ast.Operation(""), c.describeOperation("", "query {").Type)
+
+	for i := range operationCacheSize + 1 {
+		c.describeOperation("Name", fmt.Sprintf("query Name { find(id: %d) }", i))
+	}
+	require.Equal(t, operationCacheSize, c.operations.Len())
+	require.NotSame(t, operation, c.describeOperation("Name", query))
+}
+
+func TestPostOperation_requestInfo(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, newGraphQLHandler(transport.POST{}))
+
+	var operations []*Operation
+	c := NewClient(http.DefaultClient, srv.URL, nil, func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+		operations = append(operations, gqlInfo.Operation)
+
+		return next(ctx, req, gqlInfo, res)
+	})
+
+	operation := &Operation{Type: ast.Query, Name: "Name", Document: "query Name { name }", RootFields: []string{"name"}, SourceFile: "queries/name.graphql"}
+	var res nameRes
+	require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+	require.NoError(t, c.Post(context.Background(), "Name", "query Name { name }", &res, nil))
+
+	require.Len(t, operations, 2)
+	require.Same(t, operation, operations[0])
+	require.Equal(t, ast.Query, operations[1].Type)
+	require.Equal(t, []string{"name"}, operations[1].RootFields)
+}
+
+func TestStreamOperations_requestInfo(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+	operation := &Operation{Type: ast.Subscription, Name: "Name", Document: "subscription Name { name }", RootFields: []string{"name"}, SourceFile: "queries/name.graphql"}
+
+	tests := []struct {
+		name  string
+		start func(c *Client) (*Stream, error)
+	}{
+		{"websocket subscription", func(c *Client) (*Stream, error) {
+			return c.SubscribeOperation(context.Background(), operation, nil)
+		}},
+		{"SSE subscription", func(c *Client) (*Stream, error) {
+			c.SubscriptionTransport = SubscriptionTransportSSE
+
+			return c.SubscribeOperation(context.Background(), operation, nil)
+		}},
+		{"incremental delivery", func(c *Client) (*Stream, error) {
+			return c.PostIncrementalOperation(context.Background(), operation, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got *GQLRequestInfo
+			c := NewClient(http.DefaultClient, "http://127.0.0.1:0", nil, func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+				got = gqlInfo
+
+				return errStop
+			})
+
+			_, err := tt.start(c)
+			require.ErrorIs(t, err, errStop)
+			require.Same(t, operation, got.Operation)
+			require.Equal(t, "Name", got.Request.OperationName)
+			require.Equal(t, operation.Document, got.Request.Query)
+		})
+	}
+}
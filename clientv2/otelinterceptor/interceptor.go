@@ -0,0 +1,234 @@
+// Package otelinterceptor provides a clientv2.RequestInterceptor recording OpenTelemetry traces and metrics
+// of GraphQL operations, kept apart so that clientv2 does not depend on OpenTelemetry.
+package otelinterceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Yamashou/gqlgenc/clientv2/otelinterceptor"
+
+// Attributes of the spans and metrics. graphql.operation.name, graphql.operation.type and graphql.document are
+// semantic conventions, the others are specific to this package.
+const (
+	OperationNameKey = attribute.Key("graphql.operation.name")
+	OperationTypeKey = attribute.Key("graphql.operation.type")
+	DocumentKey      = attribute.Key("graphql.document")
+	DocumentHashKey  = attribute.Key("graphql.document.hash")
+	BatchSizeKey     = attribute.Key("graphql.batch.size")
+	ErrorCountKey    = attribute.Key("graphql.errors.count")
+
+	httpRequestMethodKey      = attribute.Key("http.request.method")
+	httpResponseStatusCodeKey = attribute.Key("http.response.status_code")
+	serverAddressKey          = attribute.Key("server.address")
+	serverPortKey             = attribute.Key("server.port")
+	errorTypeKey              = attribute.Key("error.type")
+)
+
+// Options configures the interceptor returned by NewInterceptor.
+type Options struct {
+	// TracerProvider creates the spans of the operations. The default is the global TracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider creates the instruments of the metrics. The default is the global MeterProvider.
+	MeterProvider metric.MeterProvider
+
+	// Propagator injects the span context into the headers of the requests. The default is the global TextMapPropagator,
+	// set it to propagation.TraceContext{} to send traceparent headers without configuring the global one.
+	Propagator propagation.TextMapPropagator
+
+	// IncludeDocument adds the document of the operations to the spans as graphql.document.
+	IncludeDocument bool
+}
+
+type instruments struct {
+	duration     metric.Float64Histogram
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+	errors       metric.Int64Counter
+}
+
+// NewInterceptor returns an interceptor that records a client span for each request, propagates its context
+// in the request headers, and records the duration, the payload sizes and the GraphQL errors of the operations.
+func NewInterceptor(options Options) (clientv2.RequestInterceptor, error) {
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
+	if options.MeterProvider == nil {
+		options.MeterProvider = otel.GetMeterProvider()
+	}
+	if options.Propagator == nil {
+		options.Propagator = otel.GetTextMapPropagator()
+	}
+
+	tracer := options.TracerProvider.Tracer(instrumentationName)
+	meter := options.MeterProvider.Meter(instrumentationName)
+
+	var (
+		inst instruments
+		err  error
+	)
+	if inst.duration, err = meter.Float64Histogram("graphql.client.operation.duration",
+		metric.WithDescription("Duration of GraphQL requests."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("create duration histogram: %w", err)
+	}
+	if inst.requestSize, err = meter.Int64Histogram("graphql.client.request.body.size",
+		metric.WithDescription("Size of GraphQL request bodies."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, fmt.Errorf("create request size histogram: %w", err)
+	}
+	if inst.responseSize, err = meter.Int64Histogram("graphql.client.response.body.size",
+		metric.WithDescription("Size of GraphQL response bodies."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, fmt.Errorf("create response size histogram: %w", err)
+	}
+	if inst.errors, err = meter.Int64Counter("graphql.client.errors",
+		metric.WithDescription("Number of GraphQL errors returned by the server."),
+		metric.WithUnit("{error}"),
+	); err != nil {
+		return nil, fmt.Errorf("create errors counter: %w", err)
+	}
+
+	return func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		operationAttrs := operationAttributes(gqlInfo)
+
+		spanAttrs := slices.Concat(operationAttrs, requestAttributes(req))
+		if operation := gqlInfo.Operation; operation != nil {
+			if operation.DocumentHash != "" {
+				spanAttrs = append(spanAttrs, DocumentHashKey.String(operation.DocumentHash))
+			}
+			if options.IncludeDocument && operation.Document != "" {
+				spanAttrs = append(spanAttrs, DocumentKey.String(operation.Document))
+			}
+		}
+
+		ctx, span := tracer.Start(ctx, spanName(gqlInfo), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttrs...))
+		defer span.End()
+
+		options.Propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		err := next(ctx, req.WithContext(ctx), gqlInfo, res)
+		elapsed := time.Since(start)
+
+		metricAttrs := operationAttrs
+		if err != nil {
+			metricAttrs = slices.Concat(operationAttrs, []attribute.KeyValue{errorTypeKey.String(errorType(err))})
+		}
+		inst.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(metricAttrs...))
+
+		if req.ContentLength > 0 {
+			inst.requestSize.Record(ctx, req.ContentLength, metric.WithAttributes(operationAttrs...))
+		}
+		if gqlInfo.ResponseBody != nil {
+			inst.responseSize.Record(ctx, int64(len(gqlInfo.ResponseBody)), metric.WithAttributes(operationAttrs...))
+		}
+		if gqlInfo.ResponseStatusCode != 0 {
+			span.SetAttributes(httpResponseStatusCodeKey.Int(gqlInfo.ResponseStatusCode))
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		var errResponse *clientv2.ErrorResponse
+		if errors.As(err, &errResponse) && errResponse.GqlErrors != nil {
+			count := len(*errResponse.GqlErrors)
+			span.SetAttributes(ErrorCountKey.Int(count))
+			inst.errors.Add(ctx, int64(count), metric.WithAttributes(operationAttrs...))
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}, nil
+}
+
+// spanName follows the semantic conventions of GraphQL spans: the operation type and name when known.
+func spanName(gqlInfo *clientv2.GQLRequestInfo) string {
+	if gqlInfo.Batch != nil {
+		return "GraphQL batch"
+	}
+
+	operation := gqlInfo.Operation
+	switch {
+	case operation == nil || operation.Type == "":
+		return "GraphQL Operation"
+	case operation.Name == "":
+		return string(operation.Type)
+	default:
+		return string(operation.Type) + " " + operation.Name
+	}
+}
+
+func operationAttributes(gqlInfo *clientv2.GQLRequestInfo) []attribute.KeyValue {
+	if gqlInfo.Batch != nil {
+		return []attribute.KeyValue{BatchSizeKey.Int(len(gqlInfo.Batch))}
+	}
+
+	operation := gqlInfo.Operation
+	if operation == nil {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if operation.Name != "" {
+		attrs = append(attrs, OperationNameKey.String(operation.Name))
+	}
+	if operation.Type != "" {
+		attrs = append(attrs, OperationTypeKey.String(string(operation.Type)))
+	}
+
+	return attrs
+}
+
+func requestAttributes(req *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{httpRequestMethodKey.String(req.Method)}
+
+	host, port, err := net.SplitHostPort(req.URL.Host)
+	if err != nil {
+		host = req.URL.Host
+	}
+	if host != "" {
+		attrs = append(attrs, serverAddressKey.String(host))
+	}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, serverPortKey.Int(p))
+	}
+
+	return attrs
+}
+
+// errorType classifies err for the error.type attribute: the status code of HTTP errors,
+// graphql for GraphQL errors and _OTHER for the rest.
+func errorType(err error) string {
+	var errResponse *clientv2.ErrorResponse
+	switch {
+	case !errors.As(err, &errResponse):
+		return "_OTHER"
+	case errResponse.NetworkError != nil:
+		return strconv.Itoa(errResponse.NetworkError.Code)
+	default:
+		return "graphql"
+	}
+}
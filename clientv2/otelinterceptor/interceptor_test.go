@@ -0,0 +1,151 @@
+package otelinterceptor_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/Yamashou/gqlgenc/clientv2/otelinterceptor"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type nameRes struct {
+	Name string `json:"name" graphql:"name"`
+}
+
+type testEnv struct {
+	client      *clientv2.Client
+	spans       *tracetest.InMemoryExporter
+	metrics     *sdkmetric.ManualReader
+	traceparent chan string
+}
+
+func newTestEnv(t *testing.T, response string) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		spans:       tracetest.NewInMemoryExporter(),
+		metrics:     sdkmetric.NewManualReader(),
+		traceparent: make(chan string, 1),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		env.traceparent <- r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	interceptor, err := otelinterceptor.NewInterceptor(otelinterceptor.Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(env.spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(env.metrics)),
+		Propagator:     propagation.TraceContext{},
+	})
+	require.NoError(t, err)
+
+	env.client = clientv2.NewClient(http.DefaultClient, srv.URL, nil, interceptor)
+
+	return env
+}
+
+func (env *testEnv) collect(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, env.metrics.Collect(context.Background(), &rm))
+
+	aggregations := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			aggregations[m.Name] = m.Data
+		}
+	}
+
+	return aggregations
+}
+
+func TestNewInterceptor(t *testing.T) {
+	t.Parallel()
+
+	operation := &clientv2.Operation{
+		Type:         "query",
+		Name:         "Name",
+		Document:     "query Name { name }",
+		DocumentHash: clientv2.DocumentHash("query Name { name }"),
+	}
+
+	t.Run("records a span and metrics of the operation", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t, `{"data":{"name":"test"}}`)
+
+		var res nameRes
+		require.NoError(t, env.client.PostOperation(context.Background(), operation, &res, nil))
+		require.Equal(t, "test", res.Name)
+
+		spans := env.spans.GetSpans()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		require.Equal(t, "query Name", span.Name)
+		require.Equal(t, trace.SpanKindClient, span.SpanKind)
+		require.Equal(t, codes.Unset, span.Status.Code)
+		require.Subset(t, span.Attributes, []attribute.KeyValue{
+			otelinterceptor.OperationNameKey.String("Name"),
+			otelinterceptor.OperationTypeKey.String("query"),
+			otelinterceptor.DocumentHashKey.String(operation.DocumentHash),
+			attribute.String("http.request.method", http.MethodPost),
+		})
+
+		traceparent := <-env.traceparent
+		require.Contains(t, traceparent, span.SpanContext.TraceID().String())
+		require.Contains(t, traceparent, span.SpanContext.SpanID().String())
+
+		metrics := env.collect(t)
+		duration, ok := metrics["graphql.client.operation.duration"].(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, duration.DataPoints, 1)
+		require.Equal(t, uint64(1), duration.DataPoints[0].Count)
+		name, _ := duration.DataPoints[0].Attributes.Value(otelinterceptor.OperationNameKey)
+		require.Equal(t, "Name", name.AsString())
+
+		responseSize, ok := metrics["graphql.client.response.body.size"].(metricdata.Histogram[int64])
+		require.True(t, ok)
+		require.Equal(t, int64(len(`{"data":{"name":"test"}}`)), responseSize.DataPoints[0].Sum)
+
+		requestSize, ok := metrics["graphql.client.request.body.size"].(metricdata.Histogram[int64])
+		require.True(t, ok)
+		require.Positive(t, requestSize.DataPoints[0].Sum)
+
+		require.NotContains(t, metrics, "graphql.client.errors")
+	})
+
+	t.Run("records GraphQL errors", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t, `{"errors":[{"message":"first"},{"message":"second"}],"data":null}`)
+
+		var res nameRes
+		require.Error(t, env.client.PostOperation(context.Background(), operation, &res, nil))
+
+		span := env.spans.GetSpans()[0]
+		require.Equal(t, codes.Error, span.Status.Code)
+		require.Contains(t, span.Attributes, otelinterceptor.ErrorCountKey.Int(2))
+
+		metrics := env.collect(t)
+		errs, ok := metrics["graphql.client.errors"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Equal(t, int64(2), errs.DataPoints[0].Value)
+
+		duration := metrics["graphql.client.operation.duration"].(metricdata.Histogram[float64])
+		errorType, _ := duration.DataPoints[0].Attributes.Value("error.type")
+		require.Equal(t, "graphql", errorType.AsString())
+	})
+}
@@ -0,0 +1,113 @@
+package clientv2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// Automatic Persisted Queries https://github.com/apollographql/apollo-link-persisted-queries#protocol
+const persistedQueryVersion = 1
+
+// PersistedQuery is the persistedQuery request extension of Automatic Persisted Queries.
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
+}
+
+// hashedRequest is a Request identified by the hash in its extensions,
+// servers reject it when the query is sent as an empty string.
+type hashedRequest struct {
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
+}
+
+// DocumentHash returns the hex encoded SHA-256 hash identifying document in persisted query requests.
+func DocumentHash(document string) string {
+	sum := sha256.Sum256([]byte(document))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// postPersisted sends the hash of the query alone, and the query along with its hash when the server does not know it yet.
+// When the server does not support persisted queries, the query alone is sent, and is sent from then on.
+func (c *Client) postPersisted(ctx context.Context, r *Request, respData any, interceptors []RequestInterceptor) error {
+	err := c.postHashed(ctx, r, respData, interceptors)
+	switch {
+	case isPersistedQueryNotSupported(err):
+		c.persistedQueriesNotSupported.Store(true)
+	case isPersistedQueryNotFound(err):
+		// sending the query with its hash registers it on the server
+		r.Extensions = persistedQueryExtensions(r.Operation.hash())
+	default:
+		return err
+	}
+
+	return c.postOrGet(ctx, r, c.requestMode(ctx) == RequestModeGET, respData, interceptors)
+}
+
+// postHashed sends the hash of the query instead of the query.
+func (c *Client) postHashed(ctx context.Context, r *Request, respData any, interceptors []RequestInterceptor) error {
+	hashed := &Request{
+		Variables:     r.Variables,
+		OperationName: r.OperationName,
+		Extensions:    persistedQueryExtensions(r.Operation.hash()),
+		Operation:     r.Operation,
+	}
+
+	useGET := c.PersistedQueriesUseGET || c.requestMode(ctx) == RequestModeGET
+
+	return c.postOrGet(ctx, hashed, useGET, respData, interceptors)
+}
+
+func persistedQueryExtensions(queryHash string) map[string]any {
+	return map[string]any{
+		"persistedQuery": PersistedQuery{
+			Version:    persistedQueryVersion,
+			SHA256Hash: queryHash,
+		},
+	}
+}
+
+func isPersistedQueryNotFound(err error) bool {
+	return hasPersistedQueryError(err, "PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
+}
+
+func isPersistedQueryNotSupported(err error) bool {
+	return hasPersistedQueryError(err, "PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+}
+
+// hasPersistedQueryError reports whether err has a GraphQL error with the message or the code.
+func hasPersistedQueryError(err error, message, code string) bool {
+	var errResponse *ErrorResponse
+	if !errors.As(err, &errResponse) || errResponse.GqlErrors == nil {
+		return false
+	}
+
+	for _, gqlErr := range *errResponse.GqlErrors {
+		if errCode, _ := gqlErr.Extensions["code"].(string); gqlErr.Message == message || errCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasUploads(vars map[string]any) bool {
+	for _, v := range vars {
+		switch v := v.(type) {
+		case graphql.Upload, []*graphql.Upload:
+			return true
+		case *graphql.Upload:
+			if v != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
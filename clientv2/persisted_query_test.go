@@ -0,0 +1,154 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// sentQuery is the method of a request and whether it sent the query, or only its hash.
+type sentQuery struct {
+	method   string
+	hasQuery bool
+}
+
+func sentQueries(t *testing.T, requests []recordedRequest) []sentQuery {
+	t.Helper()
+
+	sent := make([]sentQuery, 0, len(requests))
+	for _, req := range requests {
+		sent = append(sent, sentQuery{method: req.method, hasQuery: req.graphQLRequest(t).Query != ""})
+	}
+
+	return sent
+}
+
+// newPersistedQueryHandler returns the gqlgen test server, supporting automatic persisted queries.
+func newPersistedQueryHandler() http.Handler {
+	h := newGraphQLHandler(transport.GET{}, transport.POST{})
+	h.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](100)})
+
+	return h
+}
+
+func TestPostOperation_persistedQueries(t *testing.T) {
+	t.Parallel()
+
+	const query = "query Name { name }"
+	operation := &Operation{Type: ast.Query, Name: "Name", Document: query, DocumentHash: DocumentHash(query)}
+
+	t.Run("sends the query only when the server does not know the hash", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newPersistedQueryHandler())
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueries: true})
+
+		var res nameRes
+		require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+		require.Equal(t, "test", res.Name)
+		require.Equal(t, []sentQuery{{http.MethodPost, false}, {http.MethodPost, true}}, sentQueries(t, srv.requests()))
+
+		res = nameRes{}
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, nil))
+		require.Equal(t, "test", res.Name)
+		require.Equal(t, []sentQuery{{http.MethodPost, false}, {http.MethodPost, true}, {http.MethodPost, false}}, sentQueries(t, srv.requests()))
+	})
+
+	t.Run("hashed queries are sent as GET requests", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newPersistedQueryHandler())
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueries: true, PersistedQueriesUseGET: true})
+
+		var res nameRes
+		require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+		require.Equal(t, []sentQuery{{http.MethodGet, false}, {http.MethodPost, true}}, sentQueries(t, srv.requests()))
+
+		require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+		require.Equal(t, "test", res.Name)
+		require.Equal(t, []sentQuery{{http.MethodGet, false}, {http.MethodPost, true}, {http.MethodGet, false}}, sentQueries(t, srv.requests()))
+	})
+
+	t.Run("mutations are never sent as GET requests", func(t *testing.T) {
+		t.Parallel()
+		// the test server does not support mutations, this one knows the hash of every document
+		srv := newTestServer(t, respondWithJSON(`{"data":{"name":"test"}}`))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueries: true, PersistedQueriesUseGET: true})
+
+		const mutation = "mutation SetName { name }"
+		var res nameRes
+		require.NoError(t, c.PostOperation(context.Background(), &Operation{Type: ast.Mutation, Name: "SetName", Document: mutation}, &res, nil))
+		require.Equal(t, "test", res.Name)
+		require.Len(t, srv.requests(), 1)
+		require.Equal(t, http.MethodPost, srv.requests()[0].method)
+	})
+
+	t.Run("only the hash is sent for persisted queries only", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newPersistedQueryHandler())
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueriesOnly: true})
+
+		var res nameRes
+		err := c.PostOperation(context.Background(), operation, &res, nil)
+		require.True(t, isPersistedQueryNotFound(err))
+		require.Equal(t, []sentQuery{{http.MethodPost, false}}, sentQueries(t, srv.requests()))
+	})
+
+	t.Run("a known hash is sent as is", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, respondWithJSON(`{"data":{"name":"test"}}`))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueries: true})
+
+		var res nameRes
+		require.NoError(t, c.PostWithHash(context.Background(), "Name", query, "known", &res, nil))
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, nil))
+		require.Equal(t, "test", res.Name)
+
+		requests := srv.requests()
+		require.Len(t, requests, 2)
+		require.Equal(t, map[string]any{"version": float64(1), "sha256Hash": "known"}, requests[0].graphQLRequest(t).Extensions["persistedQuery"])
+		require.Equal(t, map[string]any{"version": float64(1), "sha256Hash": DocumentHash(query)}, requests[1].graphQLRequest(t).Extensions["persistedQuery"])
+	})
+
+	t.Run("only the query is sent once the server does not support persisted queries", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Query == "" {
+				respondWithJSON(`{"errors":[{"message":"PersistedQueryNotSupported","extensions":{"code":"PERSISTED_QUERY_NOT_SUPPORTED"}}]}`)(w, r)
+
+				return
+			}
+			respondWithJSON(`{"data":{"name":"test"}}`)(w, r)
+		}))
+		c := NewClient(http.DefaultClient, srv.URL, &Options{PersistedQueries: true})
+
+		for range 2 {
+			var res nameRes
+			require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+			require.Equal(t, "test", res.Name)
+		}
+
+		requests := srv.requests()
+		require.Equal(t, []sentQuery{{http.MethodPost, false}, {http.MethodPost, true}, {http.MethodPost, true}}, sentQueries(t, requests))
+		for _, req := range requests[1:] {
+			require.NotContains(t, req.graphQLRequest(t).Extensions, "persistedQuery")
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newPersistedQueryHandler())
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		var res nameRes
+		require.NoError(t, c.PostOperation(context.Background(), operation, &res, nil))
+		require.Equal(t, []sentQuery{{http.MethodPost, true}}, sentQueries(t, srv.requests()))
+	})
+}
@@ -0,0 +1,206 @@
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// RetryOptions configures the interceptor returned by NewRetryInterceptor.
+type RetryOptions struct {
+	// MaxAttempts is the number of times a request is sent, the first one included.
+	// The default is DefaultRetryMaxAttempts.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry, doubled for each following retry.
+	// The default is DefaultRetryInitialBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between two attempts. The wait asked by a Retry-After header is not capped,
+	// use a context deadline to bound it. The default is DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+
+	// RetryMutations allows mutations to be retried, which is only safe when they are idempotent.
+	// Operations whose type is unknown, such as documents that cannot be parsed, are treated as mutations.
+	RetryMutations bool
+}
+
+// NewRetryInterceptor returns an interceptor that sends a request again when it fails with a transport error
+// or a 429 or 5xx status. It waits for the duration of the Retry-After header when the server sends one,
+// and for an exponential backoff with jitter otherwise. It gives up when the wait would exceed the deadline of ctx.
+func NewRetryInterceptor(options RetryOptions) RequestInterceptor {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DefaultRetryMaxBackoff
+	}
+
+	return func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+		if !options.RetryMutations && !isIdempotent(gqlInfo) {
+			return next(ctx, req, gqlInfo, res)
+		}
+
+		if err := bufferBody(req); err != nil {
+			return err
+		}
+
+		for attempt := 1; ; attempt++ {
+			err := next(ctx, req, gqlInfo, res)
+			if err == nil || attempt >= options.MaxAttempts || ctx.Err() != nil {
+				return err
+			}
+
+			wait, ok := retryDelay(err, attempt, options)
+			if !ok {
+				return err
+			}
+
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return err
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return err
+			case <-timer.C:
+			}
+
+			if req, err = rewind(ctx, req); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isIdempotent reports whether every operation of the request is known to be a query.
+func isIdempotent(gqlInfo *GQLRequestInfo) bool {
+	if gqlInfo == nil || gqlInfo.Request == nil {
+		return false
+	}
+
+	operations := gqlInfo.Batch
+	if operations == nil {
+		operations = []*Request{gqlInfo.Request}
+	}
+
+	for _, r := range operations {
+		if !r.Operation.IsQuery() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bufferBody makes the body of req readable again through GetBody.
+func bufferBody(req *http.Request) error {
+	if req.GetBody != nil || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return nil
+}
+
+// rewind returns a copy of req with its body read from the start.
+func rewind(ctx context.Context, req *http.Request) (*http.Request, error) {
+	retry := req.Clone(ctx)
+	if req.GetBody == nil {
+		return retry, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	retry.Body = body
+
+	return retry, nil
+}
+
+// retryDelay returns how long to wait before sending the request again, or false when err is not retryable.
+func retryDelay(err error, attempt int, options RetryOptions) (time.Duration, bool) {
+	var errResponse *ErrorResponse
+	switch {
+	case errors.As(err, &errResponse):
+		if errResponse.NetworkError == nil {
+			return 0, false
+		}
+
+		code := errResponse.NetworkError.Code
+		if code != http.StatusTooManyRequests && code < http.StatusInternalServerError {
+			return 0, false
+		}
+
+		if wait, ok := parseRetryAfter(errResponse.NetworkError.Header.Get("Retry-After")); ok {
+			return wait, true
+		}
+	case !errors.As(err, new(*TransportError)):
+		return 0, false
+	}
+
+	return backoff(attempt, options), true
+}
+
+// backoff returns the exponential backoff of the attempt with equal jitter.
+func backoff(attempt int, options RetryOptions) time.Duration {
+	wait := options.MaxBackoff
+	if shift := attempt - 1; shift < 32 && options.InitialBackoff<<shift < options.MaxBackoff {
+		wait = options.InitialBackoff << shift
+	}
+
+	half := wait / 2
+
+	return half + rand.N(wait-half+1)
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Until(date), 0), true
+}
@@ -0,0 +1,159 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failFirst answers the first failures requests with fail, and the next ones with a name.
+func failFirst(failures int32, fail http.HandlerFunc) http.HandlerFunc {
+	var count atomic.Int32
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			fail(w, r)
+
+			return
+		}
+
+		respondWithJSON(`{"data":{"name":"test"}}`)(w, r)
+	}
+}
+
+func respondWithStatus(code int, header http.Header) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"unavailable"}]}`))
+	}
+}
+
+func TestNewRetryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	const query = "query Name { name }"
+	fastRetry := RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	t.Run("retries 5xx responses with the same body", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(2, respondWithStatus(http.StatusServiceUnavailable, nil)))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		var res nameRes
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, nil))
+		require.Equal(t, "test", res.Name)
+
+		requests := srv.requests()
+		require.Len(t, requests, 3)
+		require.Contains(t, string(requests[0].body), query)
+		require.Equal(t, requests[0].body, requests[1].body)
+		require.Equal(t, requests[0].body, requests[2].body)
+	})
+
+	t.Run("retries transport errors", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+		}))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		var res nameRes
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, nil))
+		require.Equal(t, "test", res.Name)
+		require.Len(t, srv.requests(), 2)
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, respondWithStatus(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		start := time.Now()
+		var res nameRes
+		require.NoError(t, c.Post(context.Background(), "Name", query, &res, nil))
+		require.GreaterOrEqual(t, time.Since(start), time.Second)
+		require.Len(t, srv.requests(), 2)
+	})
+
+	t.Run("gives up when Retry-After exceeds the deadline", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, respondWithStatus(http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}})))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var errResponse *ErrorResponse
+		err := c.Post(ctx, "Name", query, &nameRes{}, nil)
+		require.True(t, errors.As(err, &errResponse))
+		require.Equal(t, http.StatusTooManyRequests, errResponse.NetworkError.Code)
+		require.Len(t, srv.requests(), 1)
+	})
+
+	t.Run("returns the last error after the max attempts", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(10, respondWithStatus(http.StatusBadGateway, nil)))
+		options := fastRetry
+		options.MaxAttempts = 4
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(options))
+
+		var errResponse *ErrorResponse
+		require.True(t, errors.As(c.Post(context.Background(), "Name", query, &nameRes{}, nil), &errResponse))
+		require.Equal(t, http.StatusBadGateway, errResponse.NetworkError.Code)
+		require.Len(t, srv.requests(), 4)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, respondWithStatus(http.StatusBadRequest, nil)))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		require.Error(t, c.Post(context.Background(), "Name", query, &nameRes{}, nil))
+		require.Len(t, srv.requests(), 1)
+	})
+
+	t.Run("does not retry mutations by default", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, respondWithStatus(http.StatusServiceUnavailable, nil)))
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(fastRetry))
+
+		require.Error(t, c.Post(context.Background(), "SetName", "mutation SetName { name }", &nameRes{}, nil))
+		require.Len(t, srv.requests(), 1)
+	})
+
+	t.Run("retries mutations when allowed", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, failFirst(1, respondWithStatus(http.StatusServiceUnavailable, nil)))
+		options := fastRetry
+		options.RetryMutations = true
+		c := NewClient(http.DefaultClient, srv.URL, nil, NewRetryInterceptor(options))
+
+		require.NoError(t, c.Post(context.Background(), "SetName", "mutation SetName { name }", &nameRes{}, nil))
+		require.Len(t, srv.requests(), 2)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	wait, ok := parseRetryAfter("2")
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.InDelta(t, time.Hour, wait, float64(2*time.Second))
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
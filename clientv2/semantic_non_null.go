@@ -0,0 +1,118 @@
+package clientv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// SemanticNullError is the error when fields marked with @semanticNonNull, which are generated as non-pointer types,
+// are null because of a GraphQL error. The response data is incomplete and should not be used.
+type SemanticNullError struct {
+	// Paths are the paths of the null fields in the response.
+	Paths []ast.Path
+	// Err is the error with the GraphQL errors of the response.
+	Err error
+}
+
+func (e *SemanticNullError) Error() string {
+	paths := make([]string, 0, len(e.Paths))
+	for _, path := range e.Paths {
+		paths = append(paths, path.String())
+	}
+
+	return fmt.Sprintf("semantically non-null fields are null: %s: %s", strings.Join(paths, ", "), e.Err)
+}
+
+func (e *SemanticNullError) Unwrap() error {
+	return e.Err
+}
+
+// checkSemanticNonNull returns a *SemanticNullError wrapping err when the GraphQL errors of err made
+// a semantically non-null field of operation null in body, and err otherwise.
+func checkSemanticNonNull(operation *Operation, body []byte, err error) error {
+	if operation == nil || len(operation.SemanticNonNull) == 0 {
+		return err
+	}
+
+	gqlErrs := GraphQLErrors(err)
+	if len(gqlErrs) == 0 {
+		return err
+	}
+
+	var resp struct {
+		Data any `json:"data"`
+	}
+	if json.Unmarshal(body, &resp) != nil || resp.Data == nil {
+		return err
+	}
+
+	var paths []ast.Path
+	for _, gqlErr := range gqlErrs {
+		// the null is propagated from the field of the error up to the first nullable field,
+		// which can be a semantically non-null one
+		for i := 1; i <= len(gqlErr.Path); i++ {
+			path := gqlErr.Path[:i]
+			if !slices.Contains(operation.SemanticNonNull, semanticPath(path)) || !isNullAtPath(resp.Data, path) {
+				continue
+			}
+
+			if !slices.ContainsFunc(paths, func(p ast.Path) bool { return p.String() == path.String() }) {
+				paths = append(paths, slices.Clone(path))
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return err
+	}
+
+	return &SemanticNullError{Paths: paths, Err: err}
+}
+
+// semanticPath returns path in the format of Operation.SemanticNonNull.
+func semanticPath(path ast.Path) string {
+	var b strings.Builder
+	for _, element := range path {
+		switch element := element.(type) {
+		case ast.PathName:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(string(element))
+		case ast.PathIndex:
+			b.WriteString("[]")
+		}
+	}
+
+	return b.String()
+}
+
+// isNullAtPath reports whether the value at path in data is null, while its parent is not.
+func isNullAtPath(data any, path ast.Path) bool {
+	for _, element := range path {
+		switch element := element.(type) {
+		case ast.PathName:
+			object, ok := data.(map[string]any)
+			if !ok {
+				return false
+			}
+
+			if data, ok = object[string(element)]; !ok {
+				return false
+			}
+		case ast.PathIndex:
+			list, ok := data.([]any)
+			if !ok || int(element) < 0 || int(element) >= len(list) {
+				return false
+			}
+
+			data = list[element]
+		}
+	}
+
+	return data == nil
+}
@@ -0,0 +1,172 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestSemanticNonNull(t *testing.T) {
+	t.Parallel()
+
+	operation := &Operation{
+		Type:            ast.Query,
+		Name:            "Viewer",
+		Document:        "query Viewer { viewer { login repositories { name } } }",
+		SemanticNonNull: []string{"viewer", "viewer.login", "viewer.repositories[].name"},
+	}
+
+	type viewerRes struct {
+		Viewer struct {
+			Login        string `json:"login"`
+			Repositories []*struct {
+				Name string `json:"name"`
+			} `json:"repositories"`
+		} `json:"viewer"`
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		paths    []string
+	}{
+		{
+			name:     "null field",
+			response: `{"data":{"viewer":{"login":null,"repositories":[]}},"errors":[{"message":"failed","path":["viewer","login"]}]}`,
+			paths:    []string{"viewer.login"},
+		},
+		{
+			name:     "null list item field",
+			response: `{"data":{"viewer":{"login":"octocat","repositories":[{"name":"a"},{"name":null}]}},"errors":[{"message":"failed","path":["viewer","repositories",1,"name"]}]}`,
+			paths:    []string{"viewer.repositories[1].name"},
+		},
+		{
+			name:     "null propagated from a non-null field",
+			response: `{"data":{"viewer":null},"errors":[{"message":"failed","path":["viewer","repositories",0,"id"]}]}`,
+			paths:    []string{"viewer"},
+		},
+		{
+			name:     "nullable field",
+			response: `{"data":{"viewer":{"login":"octocat","repositories":null}},"errors":[{"message":"failed","path":["viewer","repositories"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(srv.Close)
+			c := NewClient(http.DefaultClient, srv.URL, &Options{ParseDataAlongWithErrors: true})
+
+			var res viewerRes
+			err := c.PostOperation(context.Background(), operation, &res, nil)
+			require.Len(t, GraphQLErrors(err), 1)
+
+			var semanticErr *SemanticNullError
+			if tt.paths == nil {
+				require.False(t, errors.As(err, &semanticErr))
+
+				return
+			}
+
+			require.ErrorAs(t, err, &semanticErr)
+			paths := make([]string, 0, len(semanticErr.Paths))
+			for _, path := range semanticErr.Paths {
+				paths = append(paths, path.String())
+			}
+			require.Equal(t, tt.paths, paths)
+		})
+	}
+}
+
+func TestSemanticNonNull_stream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("subscription events", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, strings.Join([]string{
+				"event: next",
+				`data: {"data":{"name":"first"}}`,
+				"",
+				"event: next",
+				`data: {"data":{"name":null},"errors":[{"message":"failed","path":["name"]}]}`,
+				"",
+				"event: complete",
+				"",
+				"",
+			}, "\n"))
+		}))
+		t.Cleanup(srv.Close)
+
+		operation := &Operation{
+			Type:            ast.Subscription,
+			Name:            "OnName",
+			Document:        "subscription OnName { name }",
+			SemanticNonNull: []string{"name"},
+		}
+		c := NewClient(http.DefaultClient, srv.URL, &Options{SubscriptionTransport: SubscriptionTransportSSE, ParseDataAlongWithErrors: true})
+		sub, err := c.SubscribeOperation(context.Background(), operation, nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		var res nameRes
+		require.NoError(t, sub.Next(&res))
+		require.Equal(t, "first", res.Name)
+
+		var semanticErr *SemanticNullError
+		require.ErrorAs(t, sub.Next(&res), &semanticErr)
+		require.Equal(t, "name", semanticErr.Paths[0].String())
+		require.Len(t, GraphQLErrors(semanticErr), 1)
+
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+	})
+
+	t.Run("incremental results", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeMultipart(w,
+				`{"data":{"viewer":{"login":"octocat","repositories":[{"name":"r0"}]}},"hasNext":true}`,
+				`{"incremental":[{"items":[{"name":"r1"},{"name":null}],"path":["viewer","repositories",1],"errors":[{"message":"failed","path":["viewer","repositories",2,"name"]}]}],"hasNext":true}`,
+				`{"incremental":[{"data":{"bio":null},"path":["viewer"],"label":"details","errors":[{"message":"failed","path":["viewer","bio"]}]}],"hasNext":false}`,
+			)
+		}))
+		t.Cleanup(srv.Close)
+
+		operation := &Operation{
+			Type:            ast.Query,
+			Name:            "Viewer",
+			Document:        "query Viewer { ... }",
+			SemanticNonNull: []string{"viewer.repositories[].name"},
+		}
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		stream, err := c.PostIncrementalOperation(context.Background(), operation, nil)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		var res repositoryRes
+		require.NoError(t, stream.Next(&res))
+
+		var semanticErr *SemanticNullError
+		require.ErrorAs(t, stream.Next(&res), &semanticErr)
+		require.Equal(t, "viewer.repositories[2].name", semanticErr.Paths[0].String())
+
+		// bio is nullable
+		err = stream.Next(&res)
+		require.Len(t, GraphQLErrors(err), 1)
+		require.False(t, errors.As(err, &semanticErr))
+
+		require.ErrorIs(t, stream.Next(&res), io.EOF)
+	})
+}
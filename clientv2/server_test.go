@@ -0,0 +1,97 @@
+package clientv2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request received by a recordingServer.
+type recordedRequest struct {
+	method string
+	url    *url.URL
+	header http.Header
+	body   []byte
+}
+
+// graphQLRequest returns the GraphQL request sent in the body or, for GET requests, in the URL.
+func (r recordedRequest) graphQLRequest(t *testing.T) *Request {
+	t.Helper()
+
+	if r.method == http.MethodGet {
+		params := r.url.Query()
+
+		return &Request{Query: params.Get("query"), OperationName: params.Get("operationName")}
+	}
+
+	var req Request
+	require.NoError(t, json.Unmarshal(r.body, &req))
+
+	return &req
+}
+
+// recordingServer is a test server recording the requests it receives.
+type recordingServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	received []recordedRequest
+}
+
+// newTestServer records each request, then passes it to handler with its body left unread.
+// The server is closed when the test ends.
+func newTestServer(t *testing.T, handler http.Handler) *recordingServer {
+	t.Helper()
+
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.received = append(s.received, recordedRequest{method: r.Method, url: r.URL, header: r.Header.Clone(), body: body})
+		s.mu.Unlock()
+
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// requests returns the requests received so far, in order.
+func (s *recordingServer) requests() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.received)
+}
+
+// newGraphQLHandler returns the gqlgen test server, which answers name with "test", with the transports.
+func newGraphQLHandler(transports ...graphql.Transport) *testserver.TestServer {
+	h := testserver.New()
+	for _, transport := range transports {
+		h.AddTransport(transport)
+	}
+
+	return h
+}
+
+// respondWithJSON answers every request with body.
+func respondWithJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}
+}
@@ -0,0 +1,93 @@
+package clientv2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// graphql-sse protocol, "distinct connections" mode https://github.com/enisdenjo/graphql-sse/blob/master/PROTOCOL.md
+const (
+	sseEventNext     = "next"
+	sseEventComplete = "complete"
+)
+
+func (s *Stream) startSSE(_ context.Context, req *http.Request, _ *GQLRequestInfo, _ any) error {
+	resp, err := s.client.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", &TransportError{Err: err})
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		return s.startSingle(resp)
+	}
+
+	s.closeTransport = resp.Body.Close
+	go s.readSSE(resp.Body)
+
+	return nil
+}
+
+func (s *Stream) readSSE(body io.ReadCloser) {
+	defer close(s.messages)
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+
+	var (
+		event string
+		data  bytes.Buffer
+	)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// the server may end the stream without a complete event
+			if !errors.Is(err, io.EOF) {
+				s.fail(err)
+			}
+
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			switch event {
+			case sseEventNext:
+				if !s.deliver(streamMessage{Type: streamNext, Payload: bytes.Clone(data.Bytes())}) {
+					return
+				}
+			case sseEventComplete:
+				s.deliver(streamMessage{Type: streamComplete})
+				return
+			}
+
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment, used by servers as keep-alive
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+
+			switch field {
+			case "event":
+				event = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+		}
+	}
+}
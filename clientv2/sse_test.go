@@ -0,0 +1,126 @@
+package clientv2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe_SSE(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives results until the server completes", func(t *testing.T) {
+		t.Parallel()
+		h := testserver.New()
+		h.AddTransport(transport.SSE{})
+		srv := httptest.NewServer(h)
+		t.Cleanup(srv.Close)
+
+		var accept string
+		interceptor := func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+			accept = req.Header.Get("Accept")
+			return next(ctx, req, gqlInfo, res)
+		}
+
+		c := NewClient(http.DefaultClient, srv.URL, &Options{SubscriptionTransport: SubscriptionTransportSSE}, interceptor)
+
+		sub, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+		require.Equal(t, "text/event-stream", accept)
+
+		for range 2 {
+			go h.SendNextSubscriptionMessage()
+
+			var res nameRes
+			require.NoError(t, sub.Next(&res))
+			require.Equal(t, "test", res.Name)
+		}
+
+		go h.SendCompleteSubscriptionMessage()
+
+		var res nameRes
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+	})
+
+	t.Run("parses multi line data and ignores comments", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, strings.Join([]string{
+				":",
+				"",
+				"event: next",
+				`data: {"data":`,
+				`data: {"name":"first"}}`,
+				"",
+				": ping",
+				"",
+				"event: next",
+				`data: {"data":{"name":"second"}}`,
+				"",
+				"event: complete",
+				"",
+				"",
+			}, "\n"))
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, &Options{SubscriptionTransport: SubscriptionTransportSSE})
+		sub, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		var names []string
+		for {
+			var res nameRes
+			err := sub.Next(&res)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, res.Name)
+		}
+		require.Equal(t, []string{"first", "second"}, names)
+	})
+
+	t.Run("non stream response is a single result", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"data":{"name":"only"}}`)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, &Options{SubscriptionTransport: SubscriptionTransportSSE})
+		sub, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		var res nameRes
+		require.NoError(t, sub.Next(&res))
+		require.Equal(t, "only", res.Name)
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+	})
+
+	t.Run("non 2xx response is a network error", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, &Options{SubscriptionTransport: SubscriptionTransportSSE})
+		_, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+
+		require.IsType(t, &ErrorResponse{}, err)
+		require.Equal(t, http.StatusForbidden, err.(*ErrorResponse).NetworkError.Code)
+	})
+}
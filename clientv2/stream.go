@@ -0,0 +1,218 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// SubscriptionTransport selects the protocol used for subscription operations.
+type SubscriptionTransport string
+
+const (
+	// SubscriptionTransportWebsocket uses the graphql-transport-ws protocol.
+	SubscriptionTransportWebsocket SubscriptionTransport = "websocket"
+	// SubscriptionTransportSSE uses the "distinct connections" mode of the graphql-sse protocol.
+	SubscriptionTransportSSE SubscriptionTransport = "sse"
+)
+
+type streamMessageType int
+
+const (
+	streamNext streamMessageType = iota
+	streamError
+	streamComplete
+)
+
+type streamMessage struct {
+	Type    streamMessageType
+	Payload json.RawMessage
+}
+
+// Stream is a running operation that delivers more than one result: a subscription,
+// or a query using @defer or @stream.
+// Results are read with Next, which must not be called concurrently.
+type Stream struct {
+	ctx       context.Context
+	client    *Client
+	operation *Operation
+	messages  chan streamMessage
+	done      chan struct{}
+	readErr   error
+	finished  bool
+
+	// for @defer and @stream every result after the initial one is a patch of it
+	incremental bool
+	received    bool
+
+	closeTransport func() error
+	closeOnce      sync.Once
+	closeErr       error
+}
+
+// Subscribe starts a subscription using the transport selected by SubscriptionTransport.
+// The interceptors receive the request that opens the connection, so headers set by them are sent to the server.
+func (c *Client) Subscribe(ctx context.Context, operationName, query string, vars map[string]any, interceptors ...RequestInterceptor) (*Stream, error) {
+	return c.SubscribeOperation(ctx, c.describeOperation(operationName, query), vars, interceptors...)
+}
+
+// SubscribeOperation is Subscribe for callers that describe the operation, as generated clients do.
+func (c *Client) SubscribeOperation(ctx context.Context, operation *Operation, vars map[string]any, interceptors ...RequestInterceptor) (*Stream, error) {
+	sub := newStream(ctx, c, operation)
+	r := &Request{
+		Query:         operation.Document,
+		Variables:     vars,
+		OperationName: operation.Name,
+		Operation:     operation,
+	}
+
+	var (
+		gqlInfo *GQLRequestInfo
+		req     *http.Request
+		start   RequestInterceptorFunc
+		err     error
+	)
+
+	switch c.SubscriptionTransport {
+	case SubscriptionTransportSSE:
+		gqlInfo, req, err = c.newRequest(ctx, r, "text/event-stream")
+		start = sub.startSSE
+	case SubscriptionTransportWebsocket:
+		fallthrough
+	default:
+		gqlInfo, req, err = c.newWebsocketRequest(ctx, r)
+		start = sub.startWebsocket
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f := c.chainInterceptors(interceptors)
+	if err := f(ctx, req, gqlInfo, sub, start); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func newStream(ctx context.Context, c *Client, operation *Operation) *Stream {
+	return &Stream{
+		ctx:            ctx,
+		client:         c,
+		operation:      operation,
+		messages:       make(chan streamMessage),
+		done:           make(chan struct{}),
+		closeTransport: func() error { return nil },
+	}
+}
+
+// startSingle handles a server that answered with a single result instead of a stream.
+func (s *Stream) startSingle(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", &TransportError{Err: err})
+	}
+
+	if resp.StatusCode < 200 || 299 < resp.StatusCode {
+		return &ErrorResponse{
+			NetworkError: &HTTPError{
+				Code:    resp.StatusCode,
+				Message: fmt.Sprintf("Response body %s", string(body)),
+			},
+		}
+	}
+
+	go func() {
+		defer close(s.messages)
+		if s.deliver(streamMessage{Type: streamNext, Payload: body}) {
+			s.deliver(streamMessage{Type: streamComplete})
+		}
+	}()
+
+	return nil
+}
+
+// deliver hands a message from the transport to Next. It returns false when the stream has been closed.
+func (s *Stream) deliver(msg streamMessage) bool {
+	select {
+	case s.messages <- msg:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// fail records the error that ended the transport, unless the stream was closed by Close.
+func (s *Stream) fail(err error) {
+	select {
+	case <-s.done:
+	default:
+		s.readErr = err
+	}
+}
+
+// Next blocks until the next result arrives and decodes it into respData.
+// It returns io.EOF once the stream has completed. Errors reported by the server
+// in a result are returned as *ErrorResponse, and the stream keeps running;
+// any other error ends the stream.
+//
+// For @defer and @stream operations the results after the initial one are merged
+// into respData, so the same value must be passed to every call.
+func (s *Stream) Next(respData any) error {
+	if s.finished {
+		return io.EOF
+	}
+
+	msg, ok := <-s.messages
+	if !ok {
+		s.finished = true
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		if s.readErr != nil {
+			return fmt.Errorf("stream connection failed: %w", s.readErr)
+		}
+
+		return io.EOF
+	}
+
+	switch msg.Type {
+	case streamNext:
+		if s.incremental {
+			return s.nextIncremental(msg.Payload, respData)
+		}
+
+		return checkSemanticNonNull(s.operation, msg.Payload, s.client.parseResponse(msg.Payload, http.StatusOK, respData))
+	case streamError:
+		s.finished = true
+
+		var errs gqlerror.List
+		if err := json.Unmarshal(msg.Payload, &errs); err != nil {
+			return fmt.Errorf("faild to parse graphql errors. Response content %s - %w", string(msg.Payload), &DecodeError{Body: msg.Payload, Err: err})
+		}
+
+		return &ErrorResponse{GqlErrors: &errs}
+	case streamComplete:
+	}
+
+	s.finished = true
+
+	return io.EOF
+}
+
+// Close stops the stream and closes the underlying connection.
+func (s *Stream) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.closeErr = s.closeTransport()
+	})
+
+	return s.closeErr
+}
@@ -0,0 +1,225 @@
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// graphql-transport-ws protocol https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const graphqlTransportWSSubprotocol = "graphql-transport-ws"
+
+type wsMessageType string
+
+const (
+	wsConnectionInitMsg wsMessageType = "connection_init"
+	wsConnectionAckMsg  wsMessageType = "connection_ack"
+	wsPingMsg           wsMessageType = "ping"
+	wsPongMsg           wsMessageType = "pong"
+	wsSubscribeMsg      wsMessageType = "subscribe"
+	wsNextMsg           wsMessageType = "next"
+	wsErrorMsg          wsMessageType = "error"
+	wsCompleteMsg       wsMessageType = "complete"
+)
+
+// each subscription uses its own connection, so a fixed id is enough
+const wsSubscriptionID = "1"
+
+const wsCloseTimeout = time.Second
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    wsMessageType   `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+func (c *Client) newWebsocketRequest(ctx context.Context, r *Request) (*GQLRequestInfo, *http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.websocketURL(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request struct failed: %w", err)
+	}
+
+	return NewGQLRequestInfo(r), req, nil
+}
+
+func (c *Client) websocketURL() string {
+	if c.WebsocketURL != "" {
+		return c.WebsocketURL
+	}
+
+	switch {
+	case strings.HasPrefix(c.BaseURL, "https://"):
+		return "wss://" + strings.TrimPrefix(c.BaseURL, "https://")
+	case strings.HasPrefix(c.BaseURL, "http://"):
+		return "ws://" + strings.TrimPrefix(c.BaseURL, "http://")
+	}
+
+	return c.BaseURL
+}
+
+func (s *Stream) startWebsocket(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, _ any) error {
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+		Subprotocols:     []string{graphqlTransportWSSubprotocol},
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, req.URL.String(), req.Header)
+	if err != nil {
+		if resp != nil {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			return &ErrorResponse{
+				NetworkError: &HTTPError{
+					Code:    resp.StatusCode,
+					Message: fmt.Sprintf("Response body %s", string(body)),
+				},
+			}
+		}
+
+		return fmt.Errorf("websocket dial failed: %w", err)
+	}
+
+	ws := &wsConn{conn: conn}
+	s.closeTransport = ws.close
+
+	// the connection lives as long as the context, Close stops the watcher
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-s.done:
+		}
+	}()
+
+	if err := ws.init(s.client.WebsocketInitPayload); err != nil {
+		_ = s.Close()
+		return err
+	}
+
+	payload, err := MarshalJSON(ctx, gqlInfo.Request)
+	if err != nil {
+		_ = s.Close()
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	if err := ws.write(wsMessage{ID: wsSubscriptionID, Type: wsSubscribeMsg, Payload: payload}); err != nil {
+		_ = s.Close()
+		return err
+	}
+
+	go ws.readLoop(s)
+
+	return nil
+}
+
+func (ws *wsConn) init(initPayload map[string]any) error {
+	initMsg := wsMessage{Type: wsConnectionInitMsg}
+	if initPayload != nil {
+		payload, err := json.Marshal(initPayload)
+		if err != nil {
+			return fmt.Errorf("encode connection_init payload: %w", err)
+		}
+		initMsg.Payload = payload
+	}
+
+	if err := ws.write(initMsg); err != nil {
+		return err
+	}
+
+	for {
+		var msg wsMessage
+		if err := ws.conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("waiting for connection_ack failed: %w", err)
+		}
+
+		switch msg.Type {
+		case wsConnectionAckMsg:
+			return nil
+		case wsPingMsg:
+			if err := ws.write(wsMessage{Type: wsPongMsg}); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected message while waiting for connection_ack: %s", msg.Type)
+		}
+	}
+}
+
+func (ws *wsConn) readLoop(s *Stream) {
+	defer close(s.messages)
+
+	for {
+		var msg wsMessage
+		if err := ws.conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				s.fail(err)
+			}
+
+			return
+		}
+
+		switch msg.Type {
+		case wsPingMsg:
+			if err := ws.write(wsMessage{Type: wsPongMsg}); err != nil {
+				s.fail(err)
+				return
+			}
+		case wsNextMsg:
+			if msg.ID == wsSubscriptionID && !s.deliver(streamMessage{Type: streamNext, Payload: msg.Payload}) {
+				return
+			}
+		case wsErrorMsg:
+			if msg.ID == wsSubscriptionID {
+				s.deliver(streamMessage{Type: streamError, Payload: msg.Payload})
+				return
+			}
+		case wsCompleteMsg:
+			if msg.ID == wsSubscriptionID {
+				s.deliver(streamMessage{Type: streamComplete})
+				return
+			}
+		case wsPongMsg, wsConnectionInitMsg, wsConnectionAckMsg, wsSubscribeMsg:
+		}
+	}
+}
+
+func (ws *wsConn) write(msg wsMessage) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	if err := ws.conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("websocket write %s failed: %w", msg.Type, err)
+	}
+
+	return nil
+}
+
+func (ws *wsConn) close() error {
+	_ = ws.write(wsMessage{ID: wsSubscriptionID, Type: wsCompleteMsg})
+
+	ws.writeMu.Lock()
+	_ = ws.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsCloseTimeout))
+	ws.writeMu.Unlock()
+
+	if err := ws.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
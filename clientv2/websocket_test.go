@@ -0,0 +1,130 @@
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/require"
+)
+
+type nameRes struct {
+	Name string `json:"name"`
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives results until the server completes", func(t *testing.T) {
+		t.Parallel()
+		h := newGraphQLHandler(transport.Websocket{PingPongInterval: 10 * time.Millisecond})
+		srv := newTestServer(t, h)
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		sub, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		for range 2 {
+			go h.SendNextSubscriptionMessage()
+
+			var res nameRes
+			require.NoError(t, sub.Next(&res))
+			require.Equal(t, "test", res.Name)
+		}
+
+		go h.SendCompleteSubscriptionMessage()
+
+		var res nameRes
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+	})
+
+	t.Run("sends init payload and interceptor headers", func(t *testing.T) {
+		t.Parallel()
+		initPayloads := make(chan transport.InitPayload, 1)
+		srv := newTestServer(t, newGraphQLHandler(transport.Websocket{
+			InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+				initPayloads <- initPayload
+				return ctx, nil, nil
+			},
+			PingPongInterval: 10 * time.Millisecond,
+		}))
+
+		var handshakeHeader http.Header
+		interceptor := func(ctx context.Context, req *http.Request, gqlInfo *GQLRequestInfo, res any, next RequestInterceptorFunc) error {
+			req.Header.Set("Authorization", "Bearer token")
+			handshakeHeader = req.Header
+			return next(ctx, req, gqlInfo, res)
+		}
+
+		c := NewClient(http.DefaultClient, srv.URL, &Options{
+			WebsocketInitPayload: map[string]any{"authToken": "token"},
+		}, interceptor)
+
+		sub, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.Equal(t, "token", (<-initPayloads).GetString("authToken"))
+		require.Equal(t, "Bearer token", handshakeHeader.Get("Authorization"))
+	})
+
+	t.Run("context cancellation ends the subscription", func(t *testing.T) {
+		t.Parallel()
+		srv := newTestServer(t, newGraphQLHandler(transport.Websocket{PingPongInterval: 10 * time.Millisecond}))
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sub, err := c.Subscribe(ctx, "OnName", "subscription OnName { name }", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		cancel()
+
+		var res nameRes
+		require.ErrorIs(t, sub.Next(&res), context.Canceled)
+		require.ErrorIs(t, sub.Next(&res), io.EOF)
+	})
+
+	t.Run("handshake failure is a network error", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		t.Cleanup(srv.Close)
+
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+		_, err := c.Subscribe(context.Background(), "OnName", "subscription OnName { name }", nil)
+
+		var errResponse *ErrorResponse
+		require.True(t, errors.As(err, &errResponse))
+		require.Equal(t, http.StatusUnauthorized, errResponse.NetworkError.Code)
+	})
+}
+
+func TestClient_websocketURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		client   *Client
+		expected string
+	}{
+		{"http", &Client{BaseURL: "http://localhost/graphql"}, "ws://localhost/graphql"},
+		{"https", &Client{BaseURL: "https://localhost/graphql"}, "wss://localhost/graphql"},
+		{"explicit", &Client{BaseURL: "https://localhost/graphql", WebsocketURL: "wss://localhost/ws"}, "wss://localhost/ws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, tt.client.websocketURL())
+		})
+	}
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -34,6 +36,15 @@ type Config struct {
 
 	Query []string `yaml:"query"`
 
+	// Name is the name of a target, which is required in Targets.
+	Name string `yaml:"name,omitempty"`
+	// Targets are generated independently from each other, each with its own schema, queries and packages.
+	// The config has no other keys when it has targets.
+	Targets []*Config `yaml:"targets,omitempty"`
+
+	// RefreshSchema introspects the endpoint even when its schema snapshot exists, and writes the snapshot again.
+	RefreshSchema bool `yaml:"-"`
+
 	// gqlgen config struct
 	GQLConfig *config.Config `yaml:"-"`
 }
@@ -51,18 +62,31 @@ func (a StringList) Has(file string) bool {
 // LoadConfigFromDefaultLocations looks for a config file in the specified directory, and all parent directories
 // walking up the tree. The closest config file will be returned.
 func LoadConfigFromDefaultLocations(dir string) (*Config, error) {
-	cfgFile, err := findCfg(dir)
+	cfgFile, err := FindConfigFile(dir)
 	if err != nil {
-		return nil, fmt.Errorf("not found Config. Config could not be found. Please make sure the name of the file is correct. want={.gqlgenc.yml, gqlgenc.yml, gqlgenc.yaml}, got=%s: %w", dir, err)
+		return nil, err
 	}
 
 	return LoadConfig(cfgFile)
 }
 
+// FindConfigFile returns the config file loaded by LoadConfigFromDefaultLocations for dir.
+func FindConfigFile(dir string) (string, error) {
+	cfgFile, err := findCfg(dir)
+	if err != nil {
+		return "", fmt.Errorf("not found Config. Config could not be found. Please make sure the name of the file is correct. want={.gqlgenc.yml, gqlgenc.yml, gqlgenc.yaml}, got=%s: %w", dir, err)
+	}
+
+	return cfgFile, nil
+}
+
 // EndPointConfig are the allowed options for the 'endpoint' config
 type EndPointConfig struct {
 	URL     string            `yaml:"url"`
 	Headers map[string]string `yaml:"headers,omitempty"`
+	// Snapshot is a file where the introspected schema is written as SDL, and loaded from instead of the endpoint
+	// unless RefreshSchema is set.
+	Snapshot string `yaml:"snapshot,omitempty"`
 }
 
 // findCfg searches for the config file in this directory and all parents up the tree
@@ -127,17 +151,72 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, fmt.Errorf("unable to parse config: %w", err)
 	}
 
-	if cfg.SchemaFilename != nil && cfg.Endpoint != nil {
-		return nil, fmt.Errorf("'schema' and 'endpoint' both specified. Use schema to load from a local file, use endpoint to load from a remote server (using introspection)")
+	if len(cfg.Targets) == 0 {
+		if cfg.Name != "" {
+			return nil, fmt.Errorf("'name' is only allowed in targets")
+		}
+
+		if err := cfg.init(); err != nil {
+			return nil, err
+		}
+
+		return &cfg, nil
+	}
+
+	if cfg.hasTargetKeys() {
+		return nil, fmt.Errorf("'targets' specified along with other keys. Set schema, endpoint, query, model, client and generate in each target")
+	}
+
+	names := make(map[string]bool, len(cfg.Targets))
+	for i, target := range cfg.Targets {
+		if target.Name == "" {
+			return nil, fmt.Errorf("targets[%d]: 'name' is required", i)
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("targets.%s: duplicated name", target.Name)
+		}
+		names[target.Name] = true
+
+		if len(target.Targets) > 0 {
+			return nil, fmt.Errorf("targets.%s: targets cannot be nested", target.Name)
+		}
+
+		if err := target.init(); err != nil {
+			return nil, fmt.Errorf("targets.%s: %w", target.Name, err)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// hasTargetKeys reports whether any key of a target is set.
+func (c *Config) hasTargetKeys() bool {
+	return c.SchemaFilename != nil || c.Endpoint != nil || c.Query != nil || c.Model.IsDefined() || c.Client.IsDefined() ||
+		c.Federation.Version != 0 || c.Models != nil || c.AutoBind != nil || c.Generate != nil
+}
+
+// AllTargets returns the targets of the config, or the config itself when it has none.
+func (c *Config) AllTargets() []*Config {
+	if len(c.Targets) == 0 {
+		return []*Config{c}
+	}
+
+	return c.Targets
+}
+
+// init validates a target read from the config file, and sets up the gqlgen config to generate it.
+func (c *Config) init() error {
+	if c.SchemaFilename != nil && c.Endpoint != nil {
+		return fmt.Errorf("'schema' and 'endpoint' both specified. Use schema to load from a local file, use endpoint to load from a remote server (using introspection)")
 	}
 
-	if cfg.SchemaFilename == nil && cfg.Endpoint == nil {
-		return nil, fmt.Errorf("neither 'schema' nor 'endpoint' specified. Use schema to load from a local file, use endpoint to load from a remote server (using introspection)")
+	if c.SchemaFilename == nil && c.Endpoint == nil {
+		return fmt.Errorf("neither 'schema' nor 'endpoint' specified. Use schema to load from a local file, use endpoint to load from a remote server (using introspection)")
 	}
 
 	// https://github.com/99designs/gqlgen/blob/3a31a752df764738b1f6e99408df3b169d514784/codegen/config/config.go#L120
 	files := StringList{}
-	for _, f := range cfg.SchemaFilename {
+	for _, f := range c.SchemaFilename {
 		var matches []string
 
 		// for ** we want to override default globbing patterns and walk all
@@ -160,12 +239,13 @@ func LoadConfig(filename string) (*Config, error) {
 
 				return nil
 			}); err != nil {
-				return nil, fmt.Errorf("failed to walk schema at root %s: %w", pathParts[0], err)
+				return fmt.Errorf("failed to walk schema at root %s: %w", pathParts[0], err)
 			}
 		} else {
+			var err error
 			matches, err = filepath.Glob(f)
 			if err != nil {
-				return nil, fmt.Errorf("failed to glob schema filename %s: %w", f, err)
+				return fmt.Errorf("failed to glob schema filename %s: %w", f, err)
 			}
 		}
 
@@ -177,23 +257,23 @@ func LoadConfig(filename string) (*Config, error) {
 	}
 
 	if len(files) > 0 {
-		cfg.SchemaFilename = files
+		c.SchemaFilename = files
 	}
 
 	models := make(config.TypeMap)
-	if cfg.Models != nil {
-		models = cfg.Models
+	if c.Models != nil {
+		models = c.Models
 	}
 
 	sources := []*ast.Source{}
 
-	for _, filename := range cfg.SchemaFilename {
+	for _, filename := range c.SchemaFilename {
 		filename = filepath.ToSlash(filename)
 		var err error
 		var schemaRaw []byte
 		schemaRaw, err = os.ReadFile(filename)
 		if err != nil {
-			return nil, fmt.Errorf("unable to open schema: %w", err)
+			return fmt.Errorf("unable to open schema: %w", err)
 		}
 
 		sources = append(sources, &ast.Source{Name: filename, Input: string(schemaRaw)})
@@ -202,44 +282,56 @@ func LoadConfig(filename string) (*Config, error) {
 	structFieldsAlwaysPointers := true
 	enableClientJsonOmitemptyTag := true
 	enableModelJsonOmitzeroTag := false
-	if cfg.Generate == nil {
-		cfg.Generate = &GenerateConfig{
+	if c.Generate == nil {
+		c.Generate = &GenerateConfig{
 			StructFieldsAlwaysPointers:   &structFieldsAlwaysPointers,
 			EnableClientJsonOmitemptyTag: &enableClientJsonOmitemptyTag,
 			EnableClientJsonOmitzeroTag:  &enableModelJsonOmitzeroTag,
 		}
 	}
-	if cfg.Generate.StructFieldsAlwaysPointers == nil {
-		cfg.Generate.StructFieldsAlwaysPointers = &structFieldsAlwaysPointers
+	if c.Generate.StructFieldsAlwaysPointers == nil {
+		c.Generate.StructFieldsAlwaysPointers = &structFieldsAlwaysPointers
 	}
-	if cfg.Generate.EnableClientJsonOmitemptyTag == nil {
-		cfg.Generate.EnableClientJsonOmitemptyTag = &enableClientJsonOmitemptyTag
+	if c.Generate.EnableClientJsonOmitemptyTag == nil {
+		c.Generate.EnableClientJsonOmitemptyTag = &enableClientJsonOmitemptyTag
 	}
-	if cfg.Generate.EnableClientJsonOmitzeroTag == nil {
-		cfg.Generate.EnableClientJsonOmitzeroTag = &enableModelJsonOmitzeroTag
+	if c.Generate.EnableClientJsonOmitzeroTag == nil {
+		c.Generate.EnableClientJsonOmitzeroTag = &enableModelJsonOmitzeroTag
 	}
 
-	cfg.GQLConfig = &config.Config{
-		Model:    cfg.Model,
+	c.GQLConfig = &config.Config{
+		Model:    c.Model,
 		Models:   models,
-		AutoBind: cfg.AutoBind,
+		AutoBind: c.AutoBind,
 		// TODO: gqlgen must be set exec but client not used
 		Exec:                           config.ExecConfig{Filename: "generated.go"},
 		Directives:                     map[string]config.DirectiveConfig{},
 		Sources:                        sources,
-		StructFieldsAlwaysPointers:     *cfg.Generate.StructFieldsAlwaysPointers,
+		StructFieldsAlwaysPointers:     *c.Generate.StructFieldsAlwaysPointers,
 		ReturnPointersInUnmarshalInput: false,
 		ResolversAlwaysReturnPointers:  true,
-		NullableInputOmittable:         cfg.Generate.NullableInputOmittable,
-		EnableModelJsonOmitemptyTag:    cfg.Generate.EnableClientJsonOmitemptyTag,
-		EnableModelJsonOmitzeroTag:     cfg.Generate.EnableClientJsonOmitzeroTag,
+		NullableInputOmittable:         c.Generate.NullableInputOmittable,
+		EnableModelJsonOmitemptyTag:    c.Generate.EnableClientJsonOmitemptyTag,
+		EnableModelJsonOmitzeroTag:     c.Generate.EnableClientJsonOmitzeroTag,
 	}
 
-	if err := cfg.Client.Check(); err != nil {
-		return nil, fmt.Errorf("config.exec: %w", err)
+	if err := c.Client.Check(); err != nil {
+		return fmt.Errorf("config.exec: %w", err)
 	}
 
-	return &cfg, nil
+	if err := c.Generate.PersistedQueryManifest.Check(); err != nil {
+		return fmt.Errorf("config.generate.persistedQueryManifest: %w", err)
+	}
+
+	if err := c.Generate.checkMock(); err != nil {
+		return fmt.Errorf("config.generate.mock: %w", err)
+	}
+
+	if err := c.Generate.checkSplit(); err != nil {
+		return fmt.Errorf("config.generate.split: %w", err)
+	}
+
+	return nil
 }
 
 // LoadSchema load and parses the schema from a local file or a remote server
@@ -253,7 +345,7 @@ func (c *Config) LoadSchema(ctx context.Context) error {
 
 		schema = s
 	} else {
-		s, err := c.loadRemoteSchema(ctx)
+		s, err := c.loadRemoteSchemaWithSnapshot(ctx)
 		if err != nil {
 			return fmt.Errorf("load remote schema failed: %w", err)
 		}
@@ -274,6 +366,47 @@ func (c *Config) LoadSchema(ctx context.Context) error {
 	return nil
 }
 
+// loadRemoteSchemaWithSnapshot loads the schema from the snapshot of the endpoint when it has one,
+// and from the endpoint otherwise, writing the snapshot.
+func (c *Config) loadRemoteSchemaWithSnapshot(ctx context.Context) (*ast.Schema, error) {
+	snapshot := c.Endpoint.Snapshot
+	if snapshot == "" {
+		return c.loadRemoteSchema(ctx)
+	}
+
+	if !c.RefreshSchema {
+		schema, err := readSchemaSnapshot(snapshot)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return schema, err
+		}
+	}
+
+	schema, err := c.loadRemoteSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeSchemaSnapshot(snapshot, c.Endpoint.URL, schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// DownloadSchema introspects the endpoint and returns the SDL of its schema.
+func (c *Config) DownloadSchema(ctx context.Context) ([]byte, error) {
+	if c.Endpoint == nil || c.Endpoint.URL == "" {
+		return nil, fmt.Errorf("'endpoint' is required to download the schema")
+	}
+
+	schema, err := c.loadRemoteSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatSchema(schema), nil
+}
+
 func (c *Config) loadRemoteSchema(ctx context.Context) (*ast.Schema, error) {
 	addHeaderInterceptor := func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
 		for key, value := range c.Endpoint.Headers {
@@ -285,8 +418,16 @@ func (c *Config) loadRemoteSchema(ctx context.Context) (*ast.Schema, error) {
 
 	gqlclient := clientv2.NewClient(http.DefaultClient, c.Endpoint.URL, nil, addHeaderInterceptor)
 
+	// the introspection query only asks for the fields the server supports, and for the ones every server has
+	// when they cannot be found out
+	var features introspection.FeaturesQuery
+	query := introspection.Introspection
+	if err := gqlclient.Post(ctx, "Features", introspection.FeaturesIntrospection, &features, nil); err == nil {
+		query = features.Features().Introspection()
+	}
+
 	var res introspection.Query
-	if err := gqlclient.Post(ctx, "Query", introspection.Introspection, &res, nil); err != nil {
+	if err := gqlclient.Post(ctx, "Query", query, &res, nil); err != nil {
 		return nil, fmt.Errorf("introspection query failed: %w", err)
 	}
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,11 +9,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/99designs/gqlgen/codegen/config"
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -106,6 +112,83 @@ func TestLoadConfig(t *testing.T) {
 		require.True(t, *c.GQLConfig.EnableModelJsonOmitemptyTag)
 		require.True(t, *c.GQLConfig.EnableModelJsonOmitzeroTag)
 	})
+
+	t.Run("persisted query manifest", func(t *testing.T) {
+		t.Parallel()
+		c, err := LoadConfig("testdata/cfg/persisted_query_manifest.yml")
+		require.NoError(t, err)
+
+		require.Equal(t, "./gen/persisted_queries.json", c.Generate.PersistedQueryManifest.Filename)
+		require.Equal(t, PersistedQueryManifestFormatRelay, c.Generate.PersistedQueryManifest.Format)
+	})
+
+	t.Run("persisted query manifest with unknown format", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/persisted_query_manifest_unknown_format.yml")
+		require.EqualError(t, err, `config.generate.persistedQueryManifest: unknown format "unknown", must be "apollo" or "relay"`)
+	})
+
+	t.Run("mock", func(t *testing.T) {
+		t.Parallel()
+		c, err := LoadConfig("testdata/cfg/mock.yml")
+		require.NoError(t, err)
+
+		require.Equal(t, "./gen/client_mock.go", c.Generate.GetMock().Filename)
+	})
+
+	t.Run("mock without client interface", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/mock_without_client_interface.yml")
+		require.EqualError(t, err, "config.generate.mock: clientInterfaceName is required, and client must not be false")
+	})
+
+	t.Run("split", func(t *testing.T) {
+		t.Parallel()
+		c, err := LoadConfig("testdata/cfg/split.yml")
+		require.NoError(t, err)
+
+		require.Equal(t, ClientSplitQueryFile, c.Generate.GetSplit())
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		t.Parallel()
+		c, err := LoadConfig("testdata/cfg/targets.yml")
+		require.NoError(t, err)
+
+		require.Len(t, c.AllTargets(), 2)
+		github, gitlab := c.Targets[0], c.Targets[1]
+		require.Equal(t, "github", github.Name)
+		require.Equal(t, StringList{"testdata/cfg/glob/foo/foo.graphql"}, github.SchemaFilename)
+		require.Equal(t, "GithubClient", *github.Generate.GetClientInterfaceName())
+		require.NotNil(t, github.GQLConfig)
+		require.Equal(t, "gitlab", gitlab.Name)
+		require.Equal(t, "https://gitlab.example.com/api/graphql", gitlab.Endpoint.URL)
+		require.NotNil(t, gitlab.GQLConfig)
+	})
+
+	t.Run("targets with other keys", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/targets_with_other_keys.yml")
+		require.EqualError(t, err, "'targets' specified along with other keys. Set schema, endpoint, query, model, client and generate in each target")
+	})
+
+	t.Run("targets with duplicated name", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/targets_duplicated.yml")
+		require.EqualError(t, err, "targets.github: duplicated name")
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/targets_invalid.yml")
+		require.EqualError(t, err, "targets.github: neither 'schema' nor 'endpoint' specified. Use schema to load from a local file, use endpoint to load from a remote server (using introspection)")
+	})
+
+	t.Run("split with unknown value", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadConfig("testdata/cfg/split_unknown.yml")
+		require.EqualError(t, err, `config.generate.split: unknown split "unknown", must be "queryFile" or "operation"`)
+	})
 }
 
 func TestLoadConfig_LoadSchema(t *testing.T) {
@@ -146,6 +229,126 @@ func TestLoadConfig_LoadSchema(t *testing.T) {
 	})
 }
 
+func TestLoadConfig_LoadSchemaFeatures(t *testing.T) {
+	t.Parallel()
+
+	// the server rejects the introspection queries asking for fields its introspection types do not have
+	newServer := func(t *testing.T, features string, unsupported []string) *httptest.Server {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var request struct{ Query string }
+			require.NoError(t, json.Unmarshal(body, &request))
+
+			if strings.HasPrefix(request.Query, "query Features") {
+				_, _ = writer.Write([]byte(features))
+
+				return
+			}
+			for _, field := range unsupported {
+				if strings.Contains(request.Query, field) {
+					_, _ = fmt.Fprintf(writer, `{"errors":[{"message":"Cannot query field \"%s\""}]}`, field)
+
+					return
+				}
+			}
+			_, _ = writer.Write(responseFromFile("testdata/remote/response_ok.json").load(t))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	t.Run("server without the fields of recent specifications", func(t *testing.T) {
+		t.Parallel()
+		srv := newServer(t, `{"data":{
+			"type":{"fields":[{"name":"kind","args":[]},{"name":"inputFields","args":[]}]},
+			"field":{"fields":[{"name":"args","args":[]}]},
+			"directive":{"fields":[{"name":"args","args":[]}]},
+			"inputValue":{"fields":[{"name":"defaultValue","args":[]}]}
+		}}`, []string{"specifiedBy", "isOneOf", "isRepeatable", "args(includeDeprecated", "inputFields(includeDeprecated"})
+
+		cfg := &Config{GQLConfig: &config.Config{}, Endpoint: &EndPointConfig{URL: srv.URL}}
+		require.NoError(t, cfg.LoadSchema(context.Background()))
+	})
+
+	t.Run("server rejecting the features query", func(t *testing.T) {
+		t.Parallel()
+		srv := newServer(t, `{"errors":[{"message":"introspection is limited"}]}`, []string{"specifiedBy", "isOneOf", "isRepeatable"})
+
+		cfg := &Config{GQLConfig: &config.Config{}, Endpoint: &EndPointConfig{URL: srv.URL}}
+		require.NoError(t, cfg.LoadSchema(context.Background()))
+	})
+}
+
+func TestLoadConfig_LoadSchemaSnapshot(t *testing.T) {
+	t.Parallel()
+
+	mockServer, closeServer := newMockRemoteServer(t, responseFromFile("testdata/remote/response_ok.json"))
+	snapshot := filepath.Join(t.TempDir(), "schema", "snapshot.graphql")
+	newConfig := func(refresh bool) *Config {
+		return &Config{
+			GQLConfig:     &config.Config{},
+			Endpoint:      &EndPointConfig{URL: mockServer.URL, Snapshot: snapshot},
+			RefreshSchema: refresh,
+		}
+	}
+
+	remote := newConfig(false)
+	require.NoError(t, remote.LoadSchema(context.Background()))
+	content, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(content), "# Code generated by github.com/Yamashou/gqlgenc from "+mockServer.URL+", DO NOT EDIT.\n# sha256: "))
+
+	// the snapshot is loaded without the endpoint
+	closeServer()
+	cached := newConfig(false)
+	require.NoError(t, cached.LoadSchema(context.Background()))
+	require.Equal(t, formatSchema(remote.GQLConfig.Schema), formatSchema(cached.GQLConfig.Schema))
+
+	err = newConfig(true).LoadSchema(context.Background())
+	require.ErrorContains(t, err, "introspection query failed")
+
+	require.NoError(t, os.WriteFile(snapshot, append(content, []byte("\nscalar Edited\n")...), 0o644))
+	err = newConfig(false).LoadSchema(context.Background())
+	require.ErrorContains(t, err, "has been modified")
+}
+
+func TestConfig_DownloadSchema(t *testing.T) {
+	t.Parallel()
+
+	mockServer, closeServer := newMockRemoteServer(t, responseFromFile("testdata/remote/response_download.json"))
+	defer closeServer()
+
+	cfg := &Config{
+		GQLConfig: &config.Config{},
+		Endpoint:  &EndPointConfig{URL: mockServer.URL},
+	}
+	sdl, err := cfg.DownloadSchema(context.Background())
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/remote/download.graphql")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(sdl))
+
+	// the SDL loads as a schema file
+	_, err = gqlparser.LoadSchema(&ast.Source{Name: "download.graphql", Input: string(sdl)})
+	require.NoError(t, err)
+
+	_, err = (&Config{GQLConfig: &config.Config{}}).DownloadSchema(context.Background())
+	require.EqualError(t, err, "'endpoint' is required to download the schema")
+}
+
+func formatSchema(schema *ast.Schema) string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(schema)
+
+	return buf.String()
+}
+
 type mockRemoteServer struct {
 	*httptest.Server
 	body []byte
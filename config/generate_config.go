@@ -1,5 +1,10 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+)
+
 type GenerateConfig struct {
 	Prefix        *NamingConfig `yaml:"prefix,omitempty"`
 	Suffix        *NamingConfig `yaml:"suffix,omitempty"`
@@ -19,6 +24,22 @@ type GenerateConfig struct {
 	ClientV2                   bool  `yaml:"clientV2,omitempty"`
 	StructFieldsAlwaysPointers *bool `yaml:"structFieldsAlwaysPointers,omitempty"`
 	OnlyUsedModels             *bool `yaml:"onlyUsedModels,omitempty"`
+
+	// if set, a manifest of the operations is written for servers that only accept persisted queries
+	PersistedQueryManifest *PersistedQueryManifestConfig `yaml:"persistedQueryManifest,omitempty"`
+	// if true, __typename and id are added to every selection set, so responses can be normalized by clientv2.Cache
+	AddCacheKeyFields bool `yaml:"addCacheKeyFields,omitempty"`
+	// if true, the fields marked with @semanticNonNull are generated as non-pointer types,
+	// and the client returns a clientv2.SemanticNullError when one of them is null because of an error
+	SemanticNonNull bool `yaml:"semanticNonNull,omitempty"`
+	// if true, the operation responses keep the GraphQL errors returned along with partial data,
+	// which their Errors method maps onto the fields
+	ResponseErrors bool `yaml:"responseErrors,omitempty"`
+	// if set, a mock of the client interface is written to a separate file of the client package
+	Mock *MockConfig `yaml:"mock,omitempty"`
+	// if set, the operations are written to separate files next to the client file,
+	// which keeps the client, the fragments and the types shared by operations
+	Split ClientSplit `yaml:"split,omitempty"`
 }
 
 func (c *GenerateConfig) ShouldGenerateClient() bool {
@@ -33,6 +54,18 @@ func (c *GenerateConfig) ShouldGenerateClient() bool {
 	return true
 }
 
+func (c *GenerateConfig) ShouldAddCacheKeyFields() bool {
+	return c != nil && c.AddCacheKeyFields
+}
+
+func (c *GenerateConfig) ShouldUseSemanticNonNull() bool {
+	return c != nil && c.SemanticNonNull
+}
+
+func (c *GenerateConfig) ShouldGenerateResponseErrors() bool {
+	return c != nil && c.ResponseErrors
+}
+
 func (c *GenerateConfig) GetClientInterfaceName() *string {
 	if c == nil {
 		return nil
@@ -45,3 +78,97 @@ type NamingConfig struct {
 	Query    string `yaml:"query,omitempty"`
 	Mutation string `yaml:"mutation,omitempty"`
 }
+
+type PersistedQueryManifestFormat string
+
+const (
+	// PersistedQueryManifestFormatApollo is the Apollo persisted query manifest, usually named operations.json.
+	PersistedQueryManifestFormatApollo PersistedQueryManifestFormat = "apollo"
+	// PersistedQueryManifestFormatRelay is a JSON object mapping ids to documents, usually named persisted_queries.json.
+	PersistedQueryManifestFormatRelay PersistedQueryManifestFormat = "relay"
+)
+
+type PersistedQueryManifestConfig struct {
+	Filename string                       `yaml:"filename"`
+	Format   PersistedQueryManifestFormat `yaml:"format,omitempty"`
+}
+
+func (c *PersistedQueryManifestConfig) Check() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Filename == "" {
+		return errors.New("filename is required")
+	}
+
+	switch c.Format {
+	case "", PersistedQueryManifestFormatApollo, PersistedQueryManifestFormatRelay:
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q, must be %q or %q", c.Format, PersistedQueryManifestFormatApollo, PersistedQueryManifestFormatRelay)
+	}
+}
+
+type ClientSplit string
+
+const (
+	// ClientSplitQueryFile writes the operations of each query file to their own file.
+	ClientSplitQueryFile ClientSplit = "queryFile"
+	// ClientSplitOperation writes each operation to its own file.
+	ClientSplitOperation ClientSplit = "operation"
+)
+
+func (c *GenerateConfig) GetSplit() ClientSplit {
+	if c == nil {
+		return ""
+	}
+
+	return c.Split
+}
+
+func (c *GenerateConfig) checkSplit() error {
+	switch c.GetSplit() {
+	case "", ClientSplitQueryFile, ClientSplitOperation:
+		return nil
+	default:
+		return fmt.Errorf("unknown split %q, must be %q or %q", c.Split, ClientSplitQueryFile, ClientSplitOperation)
+	}
+}
+
+type MockConfig struct {
+	Filename string `yaml:"filename"`
+}
+
+// checkMock validates the mock config of c, which needs the client interface it implements.
+func (c *GenerateConfig) checkMock() error {
+	if c.Mock == nil {
+		return nil
+	}
+
+	if c.Mock.Filename == "" {
+		return errors.New("filename is required")
+	}
+
+	if c.GetClientInterfaceName() == nil || !c.ShouldGenerateClient() {
+		return errors.New("clientInterfaceName is required, and client must not be false")
+	}
+
+	return nil
+}
+
+func (c *GenerateConfig) GetMock() *MockConfig {
+	if c == nil {
+		return nil
+	}
+
+	return c.Mock
+}
+
+func (c *GenerateConfig) GetPersistedQueryManifest() *PersistedQueryManifestConfig {
+	if c == nil {
+		return nil
+	}
+
+	return c.PersistedQueryManifest
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// schemaSnapshotHashPrefix starts the line of a schema snapshot with the SHA-256 of the SDL following its header.
+const schemaSnapshotHashPrefix = "# sha256: "
+
+// writeSchemaSnapshot writes the SDL of the schema introspected from url to filename, after a header recording
+// the hash of the SDL.
+func writeSchemaSnapshot(filename, url string, schema *ast.Schema) error {
+	sdl := FormatSchema(schema)
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "# Code generated by github.com/Yamashou/gqlgenc from %s, DO NOT EDIT.\n", url)
+	content.WriteString(schemaSnapshotHashPrefix + SchemaSnapshotHash(sdl) + "\n\n")
+	content.Write(sdl)
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create schema snapshot directory: %w", err)
+	}
+
+	if err := os.WriteFile(filename, content.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write schema snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FormatSchema returns the SDL of the schema, without the types and directives of the prelude of gqlparser.
+// The formatter of gqlparser leaves the root operation types with the default names out of the schema definition,
+// which then no longer declares them, so the schema definition lists all of them when it is needed.
+func FormatSchema(schema *ast.Schema) []byte {
+	formatted := *schema
+	formatted.Directives = make(map[string]*ast.DirectiveDefinition, len(schema.Directives))
+	for name, directive := range schema.Directives {
+		if !preludeDirectives()[name] {
+			formatted.Directives[name] = directive
+		}
+	}
+
+	roots := []struct {
+		operation   ast.Operation
+		definition  *ast.Definition
+		defaultName string
+	}{
+		{ast.Query, schema.Query, "Query"},
+		{ast.Mutation, schema.Mutation, "Mutation"},
+		{ast.Subscription, schema.Subscription, "Subscription"},
+	}
+
+	var sdl bytes.Buffer
+	for _, root := range roots {
+		if root.definition == nil || root.definition.Name == root.defaultName {
+			continue
+		}
+
+		sdl.WriteString("schema {\n")
+		for _, root := range roots {
+			if root.definition != nil {
+				fmt.Fprintf(&sdl, "\t%s: %s\n", root.operation, root.definition.Name)
+			}
+		}
+		sdl.WriteString("}\n")
+		formatted.Query, formatted.Mutation, formatted.Subscription = nil, nil, nil
+
+		break
+	}
+
+	formatter.NewFormatter(&sdl).FormatSchema(&formatted)
+
+	return sdl.Bytes()
+}
+
+// preludeDirectives returns the names of the directives declared by the prelude of gqlparser, which introspection
+// returns along with the directives of the schema.
+var preludeDirectives = sync.OnceValue(func() map[string]bool {
+	names := make(map[string]bool)
+	if document, err := parser.ParseSchema(validator.Prelude); err == nil {
+		for _, directive := range document.Directives {
+			names[directive.Name] = true
+		}
+	}
+
+	return names
+})
+
+// readSchemaSnapshot loads the schema of a snapshot written by writeSchemaSnapshot.
+// It fails when the SDL does not match the hash of the header.
+func readSchemaSnapshot(filename string) (*ast.Schema, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read schema snapshot: %w", err)
+	}
+
+	hash, sdl, err := splitSchemaSnapshot(content)
+	if err != nil {
+		return nil, fmt.Errorf("schema snapshot %s: %w", filename, err)
+	}
+	if actual := SchemaSnapshotHash(sdl); actual != hash {
+		return nil, fmt.Errorf("schema snapshot %s has been modified, its hash is %s but its schema hashes to %s. Regenerate it with --refresh-schema", filename, hash, actual)
+	}
+
+	// the built-in types are left out of the snapshot like from a schema file, so they come from the prelude
+	schema, gqlErr := validator.LoadSchema(validator.Prelude, &ast.Source{Name: filename, Input: string(sdl)})
+	if gqlErr != nil {
+		return nil, fmt.Errorf("validation error: %w", gqlErr)
+	}
+
+	return schema, nil
+}
+
+// splitSchemaSnapshot returns the hash recorded in the header of a snapshot, and the SDL following the header.
+func splitSchemaSnapshot(content []byte) (string, []byte, error) {
+	for len(content) > 0 && content[0] == '#' {
+		line, rest, _ := bytes.Cut(content, []byte("\n"))
+		content = rest
+
+		if hash, ok := strings.CutPrefix(string(line), schemaSnapshotHashPrefix); ok {
+			return strings.TrimSpace(hash), bytes.TrimPrefix(content, []byte("\n")), nil
+		}
+	}
+
+	return "", nil, errors.New("no hash in the header")
+}
+
+// SchemaSnapshotHash returns the hash recorded in a schema snapshot for its SDL, which changes when the schema
+// of the endpoint drifts.
+func SchemaSnapshotHash(sdl []byte) string {
+	sum := sha256.Sum256(sdl)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,106 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) *Client {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type GetViewer_Viewer_Repositories struct {
+	Name string "json:\"name\" graphql:\"name\""
+}
+
+func (t *GetViewer_Viewer_Repositories) GetName() string {
+	if t == nil {
+		t = &GetViewer_Viewer_Repositories{}
+	}
+	return t.Name
+}
+
+type GetViewer_Viewer struct {
+	Login        string                           "json:\"login\" graphql:\"login\""
+	Repositories []*GetViewer_Viewer_Repositories "json:\"repositories,omitempty\" graphql:\"repositories\""
+}
+
+func (t *GetViewer_Viewer) GetLogin() string {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.Login
+}
+func (t *GetViewer_Viewer) GetRepositories() []*GetViewer_Viewer_Repositories {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.Repositories
+}
+
+type GetViewer struct {
+	Viewer GetViewer_Viewer "json:\"viewer\" graphql:\"viewer\""
+	errors clientv2.ResponseErrors
+}
+
+func (t *GetViewer) GetViewer() *GetViewer_Viewer {
+	if t == nil {
+		t = &GetViewer{}
+	}
+	return &t.Viewer
+}
+
+// Errors returns the GraphQL errors of the response, which are only set when ParseDataWhenErrors is enabled.
+func (t *GetViewer) Errors() clientv2.ResponseErrors {
+	if t == nil {
+		return nil
+	}
+	return t.errors
+}
+
+const GetViewerDocument = `query GetViewer {
+	viewer {
+		login
+		repositories {
+			name
+		}
+	}
+}
+`
+const GetViewerDocumentHash = "96c97fa6e879af40ccbeb5cdb2fcf9a967b9955f9fc74b3fba1e3855a86c8611"
+
+var GetViewerOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "GetViewer",
+	Document:     GetViewerDocument,
+	DocumentHash: GetViewerDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "query/query.graphql",
+}
+
+func (c *Client) GetViewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*GetViewer, error) {
+	vars := map[string]any{}
+
+	var res GetViewer
+	if err := c.Client.PostOperation(ctx, GetViewerOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			res.errors = clientv2.NewResponseErrors(err)
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+var DocumentOperationNames = map[string]string{
+	GetViewerDocument: "GetViewer",
+}
@@ -0,0 +1,53 @@
+package generated
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+)
+
+const partialResponse = `{
+	"data": {"viewer": {"login": "octocat", "repositories": null}},
+	"errors": [{"message": "forbidden", "path": ["viewer", "repositories"], "extensions": {"code": "FORBIDDEN"}}]
+}`
+
+func TestClient_GetViewer_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, partialResponse)
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Run("the errors are mapped onto the fields of the partial data", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient(http.DefaultClient, srv.URL, &clientv2.Options{ParseDataAlongWithErrors: true})
+
+		res, err := c.GetViewer(context.Background())
+		require.Error(t, err)
+		require.Equal(t, "octocat", res.GetViewer().GetLogin())
+		require.Nil(t, res.GetViewer().GetRepositories())
+
+		require.Len(t, res.Errors(), 1)
+		require.Len(t, res.Errors().For("viewer"), 1)
+		require.Equal(t, "forbidden", res.Errors().For("viewer.repositories")[0].Message)
+		require.Empty(t, res.Errors().For("viewer.login"))
+		require.Len(t, res.Errors().WithCode("FORBIDDEN"), 1)
+	})
+
+	t.Run("no response without partial data", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient(http.DefaultClient, srv.URL, nil)
+
+		res, err := c.GetViewer(context.Background())
+		require.Error(t, err)
+		require.Nil(t, res)
+		require.Empty(t, res.Errors().For("viewer.repositories"))
+	})
+}
@@ -0,0 +1,396 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/Yamashou/gqlgenc/clientgenv2"
+	"github.com/Yamashou/gqlgenc/config"
+	"golang.org/x/mod/modfile"
+)
+
+// OutputFiles returns the files written by Generate for cfg. The split files of the clients are the ones on disk.
+func OutputFiles(cfg *config.Config) []string {
+	var files []string
+	for _, target := range cfg.AllTargets() {
+		files = append(files, target.Client.Filename)
+		if splitFiles, err := clientgenv2.SplitFiles(target.Client.Filename); err == nil {
+			files = append(files, splitFiles...)
+		}
+		if target.Model.IsDefined() {
+			files = append(files, target.Model.Filename)
+		}
+		if mock := target.Generate.GetMock(); mock != nil {
+			files = append(files, mock.Filename)
+		}
+		if manifest := target.Generate.GetPersistedQueryManifest(); manifest != nil {
+			files = append(files, manifest.Filename)
+		}
+	}
+
+	return files
+}
+
+// Check runs the same loading and validation as Generate, and returns the output files whose content differs
+// from what Generate would write, including the missing ones, the split files Generate would remove and the
+// missing schema snapshots of the endpoints.
+// The files are generated in a mirror of their module in a temporary directory, and compared with the files on disk,
+// which Check does not modify. Check changes the working directory to the mirror while generating, and cfg must not
+// be used afterwards.
+func Check(ctx context.Context, cfg *config.Config) ([]string, error) {
+	files, err := absFiles(append(OutputFiles(cfg), snapshotFiles(cfg)...))
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := moduleRoot(existingDir(commonDir(files)))
+	if err != nil {
+		return nil, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+	if !isWithin(wd, root) {
+		return nil, fmt.Errorf("check must run within the module of the generated files, %s", root)
+	}
+
+	tmp, err := os.MkdirTemp("", "gqlgenc-check-")
+	if err != nil {
+		return nil, fmt.Errorf("create check directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+	defer removeOnInterrupt(tmp)()
+
+	// the paths of the generated files are compared with the paths of the mirror, which must not be links
+	if tmp, err = filepath.EvalSymlinks(tmp); err != nil {
+		return nil, fmt.Errorf("resolve check directory: %w", err)
+	}
+
+	target := &checkTarget{base: root, dir: tmp}
+	if err := target.mirror(files); err != nil {
+		return nil, err
+	}
+	for _, t := range cfg.AllTargets() {
+		if err := target.redirect(t); err != nil {
+			return nil, err
+		}
+	}
+
+	// the packages are loaded from the module of the working directory
+	if err := os.Chdir(target.path(wd)); err != nil {
+		return nil, fmt.Errorf("change working directory: %w", err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	if err := Generate(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	// the split files depend on the queries, so the generated ones are only known once generated
+	generated, err := absFiles(append(OutputFiles(cfg), snapshotFiles(cfg)...))
+	if err != nil {
+		return nil, err
+	}
+	for _, filename := range generated {
+		if filename = target.original(filename); !slices.Contains(files, filename) {
+			files = append(files, filename)
+		}
+	}
+
+	var stale []string
+	for _, filename := range files {
+		current, exists, err := readOutputFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		content, generated, err := readOutputFile(target.path(filename))
+		if err != nil {
+			return nil, err
+		}
+
+		if exists != generated || !bytes.Equal(current, content) {
+			stale = append(stale, filename)
+		}
+	}
+
+	return stale, nil
+}
+
+// removeOnInterrupt removes dir when the process is interrupted, which skips the deferred calls, and interrupts the
+// process again. The returned func stops it.
+func removeOnInterrupt(dir string) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			_ = os.RemoveAll(dir)
+			if process, err := os.FindProcess(os.Getpid()); err != nil || process.Signal(sig) != nil {
+				os.Exit(1)
+			}
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// snapshotFiles returns the schema snapshots of the endpoints of cfg, which Generate writes when they are missing.
+func snapshotFiles(cfg *config.Config) []string {
+	var files []string
+	for _, target := range cfg.AllTargets() {
+		if target.SchemaFilename == nil && target.Endpoint != nil && target.Endpoint.Snapshot != "" {
+			files = append(files, target.Endpoint.Snapshot)
+		}
+	}
+
+	return files
+}
+
+func absFiles(files []string) ([]string, error) {
+	abs := make([]string, 0, len(files))
+	for _, filename := range files {
+		a, err := filepath.Abs(filename)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s: %w", filename, err)
+		}
+		if !slices.Contains(abs, a) {
+			abs = append(abs, a)
+		}
+	}
+
+	return abs, nil
+}
+
+// commonDir returns the deepest directory containing all the files.
+func commonDir(files []string) string {
+	var dir string
+	for _, filename := range files {
+		fileDir := filepath.Dir(filename)
+		if dir == "" {
+			dir = fileDir
+		}
+		for !isWithin(fileDir, dir) && dir != filepath.Dir(dir) {
+			dir = filepath.Dir(dir)
+		}
+	}
+
+	return dir
+}
+
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// existingDir returns dir, or its closest parent directory that exists.
+func existingDir(dir string) string {
+	for dir != filepath.Dir(dir) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	return dir
+}
+
+// moduleRoot returns the directory of the go.mod of the module containing dir.
+func moduleRoot(dir string) (string, error) {
+	for root := dir; ; root = filepath.Dir(root) {
+		if info, err := os.Stat(filepath.Join(root, "go.mod")); err == nil && !info.IsDir() {
+			return root, nil
+		}
+		if root == filepath.Dir(root) {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+func readOutputFile(filename string) ([]byte, bool, error) {
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("read %s: %w", filename, err)
+	}
+
+	return content, true, nil
+}
+
+// checkTarget moves the output files of Check from the module at base to its mirror in dir, keeping their paths
+// relative to base.
+type checkTarget struct {
+	base string
+	dir  string
+}
+
+func (t *checkTarget) path(filename string) string {
+	rel, err := filepath.Rel(t.base, filename)
+	if err != nil {
+		return filename
+	}
+
+	return filepath.Join(t.dir, rel)
+}
+
+func (t *checkTarget) original(filename string) string {
+	rel, err := filepath.Rel(t.dir, filename)
+	if err != nil || !isWithin(filename, t.dir) {
+		return filename
+	}
+
+	return filepath.Join(t.base, rel)
+}
+
+// redirect points the output files and the schema snapshot of cfg to dir.
+func (t *checkTarget) redirect(cfg *config.Config) error {
+	var err error
+	redirect := func(filename string) string {
+		abs, absErr := filepath.Abs(filename)
+		if absErr != nil {
+			err = errors.Join(err, fmt.Errorf("resolve %s: %w", filename, absErr))
+
+			return filename
+		}
+
+		return t.path(abs)
+	}
+
+	cfg.Client.Filename = redirect(cfg.Client.Filename)
+	if cfg.Model.IsDefined() {
+		cfg.Model.Filename = redirect(cfg.Model.Filename)
+		cfg.GQLConfig.Model.Filename = cfg.Model.Filename
+	}
+
+	if cfg.Generate != nil {
+		generate := *cfg.Generate
+		if generate.Mock != nil {
+			mock := *generate.Mock
+			mock.Filename = redirect(mock.Filename)
+			generate.Mock = &mock
+		}
+		if generate.PersistedQueryManifest != nil {
+			manifest := *generate.PersistedQueryManifest
+			manifest.Filename = redirect(manifest.Filename)
+			generate.PersistedQueryManifest = &manifest
+		}
+		cfg.Generate = &generate
+	}
+
+	if len(snapshotFiles(cfg)) > 0 {
+		endpoint := *cfg.Endpoint
+		endpoint.Snapshot = redirect(endpoint.Snapshot)
+		cfg.Endpoint = &endpoint
+	}
+
+	return err
+}
+
+// mirror creates in dir the directories of files, with links to the other entries of the same directories of base,
+// so that the module of base is loaded from dir while the files written there stay in dir. The files, go.mod and
+// go.sum are copied instead of linked, since they may be written.
+func (t *checkTarget) mirror(files []string) error {
+	dirs := map[string]bool{t.base: true}
+	for _, filename := range files {
+		for dir := filepath.Dir(filename); isWithin(dir, t.base) && !dirs[dir]; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	for dir := range dirs {
+		if err := os.MkdirAll(t.path(dir), 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", t.path(dir), err)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			filename := filepath.Join(dir, entry.Name())
+			switch {
+			case dirs[filename]:
+				continue
+			case dir == t.base && entry.Name() == "go.mod":
+				err = t.copyGoMod(filename)
+			case slices.Contains(files, filename) || dir == t.base && entry.Name() == "go.sum":
+				err = copyFile(filename, t.path(filename))
+			default:
+				err = os.Symlink(filename, t.path(filename))
+			}
+			if err != nil {
+				return fmt.Errorf("mirror %s: %w", filename, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyGoMod copies the go.mod of base to dir, with the relative paths of its replacements made absolute.
+func (t *checkTarget) copyGoMod(filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", filename, err)
+	}
+
+	goMod, err := modfile.Parse(filename, content, nil)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", filename, err)
+	}
+	for _, replace := range goMod.Replace {
+		if replace.New.Version == "" && !filepath.IsAbs(replace.New.Path) {
+			if err := goMod.AddReplace(replace.Old.Path, replace.Old.Version, filepath.Join(t.base, replace.New.Path), ""); err != nil {
+				return fmt.Errorf("replace %s: %w", replace.Old.Path, err)
+			}
+		}
+	}
+
+	if content, err = goMod.Format(); err != nil {
+		return fmt.Errorf("format %s: %w", filename, err)
+	}
+
+	return writeFile(t.path(filename), content)
+}
+
+func copyFile(src, dst string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", src, err)
+	}
+
+	return writeFile(dst, content)
+}
+
+func writeFile(filename string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(filename), err)
+	}
+
+	if err := os.WriteFile(filename, content, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", filename, err)
+	}
+
+	return nil
+}
@@ -35,11 +35,46 @@ func mutateHook(cfg *config.Config, usedTypes map[string]bool) func(b *modelgen.
 	}
 }
 
+// TargetError is the error of a target of the config, which does not stop the generation of the other targets.
+type TargetError struct {
+	Target string
+	Err    error
+}
+
+func (e *TargetError) Error() string {
+	return fmt.Sprintf("target %s: %v", e.Target, e.Err)
+}
+
+func (e *TargetError) Unwrap() error {
+	return e.Err
+}
+
+// Generate generates the client of the config, or of each of its targets. The errors of the targets are returned
+// together as *TargetError.
 func Generate(ctx context.Context, cfg *config.Config) error {
+	if len(cfg.Targets) == 0 {
+		return generateTarget(ctx, cfg)
+	}
+
+	var errs []error
+	for _, target := range cfg.Targets {
+		if err := generateTarget(ctx, target); err != nil {
+			errs = append(errs, &TargetError{Target: target.Name, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func generateTarget(ctx context.Context, cfg *config.Config) error {
 	_ = syscall.Unlink(cfg.Client.Filename)
 	if cfg.Model.IsDefined() {
 		_ = syscall.Unlink(cfg.Model.Filename)
 	}
+	// the mock of a previous generation may use operations that are removed
+	if mock := cfg.Generate.GetMock(); mock != nil {
+		_ = syscall.Unlink(mock.Filename)
+	}
 
 	if cfg.Federation.Version != 0 {
 		var (
@@ -90,6 +125,12 @@ func Generate(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf(": %w", err)
 	}
 
+	if cfg.Generate.ShouldAddCacheKeyFields() {
+		if err := querydocument.AddCacheKeyFields(cfg.GQLConfig.Schema, queryDocument); err != nil {
+			return fmt.Errorf("add cache key fields failed: %w", err)
+		}
+	}
+
 	operationQueryDocuments, err := querydocument.QueryDocumentsByOperations(cfg.GQLConfig.Schema, queryDocument.Operations)
 	if err != nil {
 		return fmt.Errorf(": %w", err)
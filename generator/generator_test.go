@@ -2,6 +2,8 @@ package generator_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -38,8 +40,10 @@ func (s *Suite) TestGenerator_withTestData() {
 			s.Require().NoError(err)
 
 			// disable unnecessary validations
-			cfg.GQLConfig.SkipValidation = true
-			cfg.GQLConfig.SkipModTidy = true
+			for _, target := range cfg.AllTargets() {
+				target.GQLConfig.SkipValidation = true
+				target.GQLConfig.SkipModTidy = true
+			}
 
 			// generate code
 			err = generator.Generate(context.Background(), cfg)
@@ -81,6 +85,185 @@ func (s *Suite) TestGenerator_withTestData() {
 	}
 }
 
+func (s *Suite) TestCheck() {
+	s.useDirForTest(filepath.Join("testdata", "persisted_query_manifest"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	loadConfig := func() *config.Config {
+		cfg, err := config.LoadConfig("./gqlgenc.yml")
+		s.Require().NoError(err)
+		cfg.GQLConfig.SkipValidation = true
+		cfg.GQLConfig.SkipModTidy = true
+
+		return cfg
+	}
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	clientFile := filepath.Join(wd, actual, "client.go")
+
+	// missing files are stale, and are not created
+	stale, err := generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Len(stale, 3)
+	s.NoDirExists(actual)
+	checkDirs, err := filepath.Glob(filepath.Join(os.TempDir(), "gqlgenc-check-*"))
+	s.Require().NoError(err)
+	s.Empty(checkDirs)
+	checkWd, err := os.Getwd()
+	s.Require().NoError(err)
+	s.Equal(wd, checkWd)
+
+	s.Require().NoError(generator.Generate(context.Background(), loadConfig()))
+	stale, err = generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Empty(stale)
+
+	// modified files are stale, and are left untouched
+	s.Require().NoError(os.WriteFile(clientFile, []byte("package generated\n"), 0o644))
+	stale, err = generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Equal([]string{clientFile}, stale)
+	content, err := os.ReadFile(clientFile)
+	s.Require().NoError(err)
+	s.Equal("package generated\n", string(content))
+
+	// invalid queries fail
+	broken := filepath.Join(s.T().TempDir(), "broken.graphql")
+	s.Require().NoError(os.WriteFile(broken, []byte("query Broken { unknownField }"), 0o644))
+	cfg := loadConfig()
+	cfg.Query = append(cfg.Query, broken)
+	_, err = generator.Check(context.Background(), cfg)
+	s.Require().Error(err)
+	content, err = os.ReadFile(clientFile)
+	s.Require().NoError(err)
+	s.Equal("package generated\n", string(content))
+}
+
+func (s *Suite) TestCheck_schemaSnapshot() {
+	s.useDirForTest(filepath.Join("testdata", "persisted_query_manifest"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	introspection, err := os.ReadFile(filepath.Join("..", "..", "..", "config", "testdata", "remote", "response_download.json"))
+	s.Require().NoError(err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(introspection)
+	}))
+	s.T().Cleanup(srv.Close)
+
+	query := filepath.Join(s.T().TempDir(), "users.graphql")
+	s.Require().NoError(os.WriteFile(query, []byte("query Users { users { id name } }"), 0o644))
+
+	loadConfig := func() *config.Config {
+		cfg, err := config.LoadConfig("./gqlgenc.yml")
+		s.Require().NoError(err)
+		cfg.GQLConfig.SkipValidation = true
+		cfg.GQLConfig.SkipModTidy = true
+		cfg.SchemaFilename = nil
+		cfg.Endpoint = &config.EndPointConfig{URL: srv.URL, Snapshot: "./actual/schema.graphql"}
+		cfg.Query = []string{query}
+
+		return cfg
+	}
+	wd, err := os.Getwd()
+	s.Require().NoError(err)
+	snapshot := filepath.Join(wd, actual, "schema.graphql")
+
+	// a missing snapshot is stale, and is not written
+	stale, err := generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Contains(stale, snapshot)
+	s.NoDirExists(actual)
+
+	s.Require().NoError(generator.Generate(context.Background(), loadConfig()))
+	s.FileExists(snapshot)
+	stale, err = generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Empty(stale)
+}
+
+func (s *Suite) TestCheck_modelPackage() {
+	s.useDirForTest(filepath.Join("testdata", "only_used_models"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	loadConfig := func() *config.Config {
+		cfg, err := config.LoadConfig("./gqlgenc.yml")
+		s.Require().NoError(err)
+		cfg.GQLConfig.SkipValidation = true
+		cfg.GQLConfig.SkipModTidy = true
+		cfg.Model.Filename = filepath.Join(actual, "model", "models_gen.go")
+		cfg.Model.Package = "model"
+		cfg.GQLConfig.Model = cfg.Model
+
+		return cfg
+	}
+
+	// the client imports the model package by the path it has once generated
+	s.Require().NoError(generator.Generate(context.Background(), loadConfig()))
+	stale, err := generator.Check(context.Background(), loadConfig())
+	s.Require().NoError(err)
+	s.Empty(stale)
+}
+
+func (s *Suite) TestSplitStaleFiles() {
+	s.useDirForTest(filepath.Join("testdata", "split"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	loadConfig := func(split config.ClientSplit) *config.Config {
+		cfg, err := config.LoadConfig("./gqlgenc.yml")
+		s.Require().NoError(err)
+		cfg.GQLConfig.SkipValidation = true
+		cfg.GQLConfig.SkipModTidy = true
+		cfg.Generate.Split = split
+
+		return cfg
+	}
+
+	s.Require().NoError(generator.Generate(context.Background(), loadConfig(config.ClientSplitOperation)))
+	s.Len(s.loadFiles(actual), 5, "client, models and a file per operation")
+
+	// split files are stale when generating a single file, and removed by generate
+	stale, err := generator.Check(context.Background(), loadConfig(""))
+	s.Require().NoError(err)
+	s.Len(stale, 4, "client and the files of the operations")
+	s.Len(s.loadFiles(actual), 5)
+
+	s.Require().NoError(generator.Generate(context.Background(), loadConfig("")))
+	s.Len(s.loadFiles(actual), 2)
+
+	// split files to be created are stale, and removed by check
+	stale, err = generator.Check(context.Background(), loadConfig(config.ClientSplitQueryFile))
+	s.Require().NoError(err)
+	s.Len(stale, 3)
+	s.Len(s.loadFiles(actual), 2)
+}
+
+func (s *Suite) TestTargets() {
+	s.useDirForTest(filepath.Join("testdata", "targets"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	cfg, err := config.LoadConfig("./gqlgenc.yml")
+	s.Require().NoError(err)
+
+	// an invalid target does not stop the generation of the others
+	broken := filepath.Join(s.T().TempDir(), "broken.graphql")
+	s.Require().NoError(os.WriteFile(broken, []byte("query Broken {\n  unknownField\n}\n"), 0o644))
+	cfg.Targets[1].Query = append(cfg.Targets[1].Query, broken)
+
+	err = generator.Generate(context.Background(), cfg)
+	var targetErr *generator.TargetError
+	s.Require().ErrorAs(err, &targetErr)
+	s.Equal("todos", targetErr.Target)
+	s.Contains(generator.FormatError(err), "target todos: "+broken+":2: ")
+	s.FileExists(filepath.Join(actual, "client.go"))
+	s.NoFileExists(filepath.Join(actual, "todos", "client.go"))
+}
+
 // useDir changes the current working directory to the given directory
 // and returns a function that can be used to restore the original
 // working directory.
@@ -0,0 +1,634 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) *Client {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type TodoFields struct {
+	Text     string  "json:\"text\" graphql:\"text\""
+	Done     bool    "json:\"done\" graphql:\"done\""
+	Typename *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	ID       string  "json:\"id\" graphql:\"id\""
+}
+
+func (t *TodoFields) GetText() string {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.Text
+}
+func (t *TodoFields) GetDone() bool {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.Done
+}
+func (t *TodoFields) GetTypename() *string {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.Typename
+}
+func (t *TodoFields) GetID() string {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.ID
+}
+
+type UserLogin struct {
+	ID        string  "json:\"id\" graphql:\"id\""
+	Typename  *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	GqlgencID string  "json:\"_gqlgencId\" graphql:\"_gqlgencId\""
+}
+
+func (t *UserLogin) GetID() string {
+	if t == nil {
+		t = &UserLogin{}
+	}
+	return t.ID
+}
+func (t *UserLogin) GetTypename() *string {
+	if t == nil {
+		t = &UserLogin{}
+	}
+	return t.Typename
+}
+func (t *UserLogin) GetGqlgencID() string {
+	if t == nil {
+		t = &UserLogin{}
+	}
+	return t.GqlgencID
+}
+
+type Viewer_Viewer_Settings struct {
+	Typename *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Theme    string  "json:\"theme\" graphql:\"theme\""
+}
+
+func (t *Viewer_Viewer_Settings) GetTypename() *string {
+	if t == nil {
+		t = &Viewer_Viewer_Settings{}
+	}
+	return t.Typename
+}
+func (t *Viewer_Viewer_Settings) GetTheme() string {
+	if t == nil {
+		t = &Viewer_Viewer_Settings{}
+	}
+	return t.Theme
+}
+
+type Viewer_Viewer_Todos struct {
+	Typename *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Done     bool    "json:\"done\" graphql:\"done\""
+	ID       string  "json:\"id\" graphql:\"id\""
+	Text     string  "json:\"text\" graphql:\"text\""
+}
+
+func (t *Viewer_Viewer_Todos) GetTypename() *string {
+	if t == nil {
+		t = &Viewer_Viewer_Todos{}
+	}
+	return t.Typename
+}
+func (t *Viewer_Viewer_Todos) GetDone() bool {
+	if t == nil {
+		t = &Viewer_Viewer_Todos{}
+	}
+	return t.Done
+}
+func (t *Viewer_Viewer_Todos) GetID() string {
+	if t == nil {
+		t = &Viewer_Viewer_Todos{}
+	}
+	return t.ID
+}
+func (t *Viewer_Viewer_Todos) GetText() string {
+	if t == nil {
+		t = &Viewer_Viewer_Todos{}
+	}
+	return t.Text
+}
+
+type Viewer_Viewer struct {
+	Typename *string                "json:\"__typename,omitempty\" graphql:\"__typename\""
+	ID       string                 "json:\"id\" graphql:\"id\""
+	Login    string                 "json:\"login\" graphql:\"login\""
+	Settings Viewer_Viewer_Settings "json:\"settings\" graphql:\"settings\""
+	Todos    []*Viewer_Viewer_Todos "json:\"todos\" graphql:\"todos\""
+}
+
+func (t *Viewer_Viewer) GetTypename() *string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Typename
+}
+func (t *Viewer_Viewer) GetID() string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.ID
+}
+func (t *Viewer_Viewer) GetLogin() string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Login
+}
+func (t *Viewer_Viewer) GetSettings() *Viewer_Viewer_Settings {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return &t.Settings
+}
+func (t *Viewer_Viewer) GetTodos() []*Viewer_Viewer_Todos {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Todos
+}
+
+type Search_Search_User struct {
+	Login string "json:\"login\" graphql:\"login\""
+	ID    string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_User) GetLogin() string {
+	if t == nil {
+		t = &Search_Search_User{}
+	}
+	return t.Login
+}
+func (t *Search_Search_User) GetID() string {
+	if t == nil {
+		t = &Search_Search_User{}
+	}
+	return t.ID
+}
+
+type Search_Search_Todo struct {
+	Text string "json:\"text\" graphql:\"text\""
+	ID   string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Todo) GetText() string {
+	if t == nil {
+		t = &Search_Search_Todo{}
+	}
+	return t.Text
+}
+func (t *Search_Search_Todo) GetID() string {
+	if t == nil {
+		t = &Search_Search_Todo{}
+	}
+	return t.ID
+}
+
+type Search_Search struct {
+	Todo     Search_Search_Todo "graphql:\"... on Todo\""
+	User     Search_Search_User "graphql:\"... on User\""
+	Typename *string            "json:\"__typename,omitempty\" graphql:\"__typename\""
+}
+
+func (t *Search_Search) GetTodo() *Search_Search_Todo {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return &t.Todo
+}
+func (t *Search_Search) GetUser() *Search_Search_User {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return &t.User
+}
+func (t *Search_Search) GetTypename() *string {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Typename
+}
+
+type UpdateTodo_UpdateTodo struct {
+	Typename *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Done     bool    "json:\"done\" graphql:\"done\""
+	ID       string  "json:\"id\" graphql:\"id\""
+}
+
+func (t *UpdateTodo_UpdateTodo) GetTypename() *string {
+	if t == nil {
+		t = &UpdateTodo_UpdateTodo{}
+	}
+	return t.Typename
+}
+func (t *UpdateTodo_UpdateTodo) GetDone() bool {
+	if t == nil {
+		t = &UpdateTodo_UpdateTodo{}
+	}
+	return t.Done
+}
+func (t *UpdateTodo_UpdateTodo) GetID() string {
+	if t == nil {
+		t = &UpdateTodo_UpdateTodo{}
+	}
+	return t.ID
+}
+
+type ViewerLogin_Viewer struct {
+	Typename  *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	GqlgencID string  "json:\"_gqlgencId\" graphql:\"_gqlgencId\""
+	ID        string  "json:\"id\" graphql:\"id\""
+}
+
+func (t *ViewerLogin_Viewer) GetTypename() *string {
+	if t == nil {
+		t = &ViewerLogin_Viewer{}
+	}
+	return t.Typename
+}
+func (t *ViewerLogin_Viewer) GetGqlgencID() string {
+	if t == nil {
+		t = &ViewerLogin_Viewer{}
+	}
+	return t.GqlgencID
+}
+func (t *ViewerLogin_Viewer) GetID() string {
+	if t == nil {
+		t = &ViewerLogin_Viewer{}
+	}
+	return t.ID
+}
+
+type ViewerFragments_Viewer_User struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *ViewerFragments_Viewer_User) GetID() string {
+	if t == nil {
+		t = &ViewerFragments_Viewer_User{}
+	}
+	return t.ID
+}
+
+type ViewerFragments_Viewer_Settings struct {
+	Typename *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Theme    string  "json:\"theme\" graphql:\"theme\""
+}
+
+func (t *ViewerFragments_Viewer_Settings) GetTypename() *string {
+	if t == nil {
+		t = &ViewerFragments_Viewer_Settings{}
+	}
+	return t.Typename
+}
+func (t *ViewerFragments_Viewer_Settings) GetTheme() string {
+	if t == nil {
+		t = &ViewerFragments_Viewer_Settings{}
+	}
+	return t.Theme
+}
+
+type ViewerFragments_Viewer struct {
+	User     ViewerFragments_Viewer_User     "graphql:\"... on User\""
+	Typename *string                         "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Settings ViewerFragments_Viewer_Settings "json:\"settings\" graphql:\"settings\""
+}
+
+func (t *ViewerFragments_Viewer) GetUser() *ViewerFragments_Viewer_User {
+	if t == nil {
+		t = &ViewerFragments_Viewer{}
+	}
+	return &t.User
+}
+func (t *ViewerFragments_Viewer) GetTypename() *string {
+	if t == nil {
+		t = &ViewerFragments_Viewer{}
+	}
+	return t.Typename
+}
+func (t *ViewerFragments_Viewer) GetSettings() *ViewerFragments_Viewer_Settings {
+	if t == nil {
+		t = &ViewerFragments_Viewer{}
+	}
+	return &t.Settings
+}
+
+type ViewerFragments_Other struct {
+	Typename  *string "json:\"__typename,omitempty\" graphql:\"__typename\""
+	GqlgencID string  "json:\"_gqlgencId\" graphql:\"_gqlgencId\""
+	ID        string  "json:\"id\" graphql:\"id\""
+}
+
+func (t *ViewerFragments_Other) GetTypename() *string {
+	if t == nil {
+		t = &ViewerFragments_Other{}
+	}
+	return t.Typename
+}
+func (t *ViewerFragments_Other) GetGqlgencID() string {
+	if t == nil {
+		t = &ViewerFragments_Other{}
+	}
+	return t.GqlgencID
+}
+func (t *ViewerFragments_Other) GetID() string {
+	if t == nil {
+		t = &ViewerFragments_Other{}
+	}
+	return t.ID
+}
+
+type Viewer struct {
+	Viewer Viewer_Viewer "json:\"viewer\" graphql:\"viewer\""
+}
+
+func (t *Viewer) GetViewer() *Viewer_Viewer {
+	if t == nil {
+		t = &Viewer{}
+	}
+	return &t.Viewer
+}
+
+type Search struct {
+	Search []*Search_Search "json:\"search\" graphql:\"search\""
+}
+
+func (t *Search) GetSearch() []*Search_Search {
+	if t == nil {
+		t = &Search{}
+	}
+	return t.Search
+}
+
+type UpdateTodo struct {
+	UpdateTodo UpdateTodo_UpdateTodo "json:\"updateTodo\" graphql:\"updateTodo\""
+}
+
+func (t *UpdateTodo) GetUpdateTodo() *UpdateTodo_UpdateTodo {
+	if t == nil {
+		t = &UpdateTodo{}
+	}
+	return &t.UpdateTodo
+}
+
+type ViewerLogin struct {
+	Viewer ViewerLogin_Viewer "json:\"viewer\" graphql:\"viewer\""
+}
+
+func (t *ViewerLogin) GetViewer() *ViewerLogin_Viewer {
+	if t == nil {
+		t = &ViewerLogin{}
+	}
+	return &t.Viewer
+}
+
+type ViewerFragments struct {
+	Viewer ViewerFragments_Viewer "json:\"viewer\" graphql:\"viewer\""
+	Other  ViewerFragments_Other  "json:\"other\" graphql:\"other\""
+}
+
+func (t *ViewerFragments) GetViewer() *ViewerFragments_Viewer {
+	if t == nil {
+		t = &ViewerFragments{}
+	}
+	return &t.Viewer
+}
+func (t *ViewerFragments) GetOther() *ViewerFragments_Other {
+	if t == nil {
+		t = &ViewerFragments{}
+	}
+	return &t.Other
+}
+
+const ViewerDocument = `query Viewer {
+	viewer {
+		login
+		settings {
+			theme
+			__typename
+		}
+		todos {
+			... TodoFields
+			__typename
+			id
+		}
+		__typename
+		id
+	}
+}
+fragment TodoFields on Todo {
+	text
+	done
+	__typename
+	id
+}
+`
+const ViewerDocumentHash = "8e3c497da3a9aedef18c394874232654f0c85df75348f4df91992675bc73ff02"
+
+var ViewerOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Viewer",
+	Document:     ViewerDocument,
+	DocumentHash: ViewerDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "queries/queries.graphql",
+}
+
+func (c *Client) Viewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Viewer, error) {
+	vars := map[string]any{}
+
+	var res Viewer
+	if err := c.Client.PostOperation(ctx, ViewerOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SearchDocument = `query Search ($text: String!) {
+	search(text: $text) {
+		... on User {
+			login
+			id
+		}
+		... on Todo {
+			text
+			id
+		}
+		__typename
+	}
+}
+`
+const SearchDocumentHash = "e3a2ace87740d056086d948e30426575e33b3107633047bfadae3e5ed6858ad4"
+
+var SearchOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Search",
+	Document:     SearchDocument,
+	DocumentHash: SearchDocumentHash,
+	RootFields:   []string{"search"},
+	SourceFile:   "queries/queries.graphql",
+}
+
+func (c *Client) Search(ctx context.Context, text string, interceptors ...clientv2.RequestInterceptor) (*Search, error) {
+	vars := map[string]any{
+		"text": text,
+	}
+
+	var res Search
+	if err := c.Client.PostOperation(ctx, SearchOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateTodoDocument = `mutation UpdateTodo ($id: ID!, $done: Boolean!) {
+	updateTodo(id: $id, done: $done) {
+		id
+		done
+		__typename
+	}
+}
+`
+const UpdateTodoDocumentHash = "27e583ab1cd9f9166200b39d390e26c055aa63f4c3f0d97339076cb0946b63b2"
+
+var UpdateTodoOperation = &clientv2.Operation{
+	Type:         "mutation",
+	Name:         "UpdateTodo",
+	Document:     UpdateTodoDocument,
+	DocumentHash: UpdateTodoDocumentHash,
+	RootFields:   []string{"updateTodo"},
+	SourceFile:   "queries/queries.graphql",
+}
+
+func (c *Client) UpdateTodo(ctx context.Context, id string, done bool, interceptors ...clientv2.RequestInterceptor) (*UpdateTodo, error) {
+	vars := map[string]any{
+		"id":   id,
+		"done": done,
+	}
+
+	var res UpdateTodo
+	if err := c.Client.PostOperation(ctx, UpdateTodoOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ViewerLoginDocument = `query ViewerLogin {
+	viewer {
+		id: login
+		__typename
+		_gqlgencId: id
+	}
+}
+`
+const ViewerLoginDocumentHash = "2a624868662f26e636ee49eaa8acc59e05fbc3e9dbf362e5ddc10a2778600a12"
+
+var ViewerLoginOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "ViewerLogin",
+	Document:     ViewerLoginDocument,
+	DocumentHash: ViewerLoginDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "queries/queries.graphql",
+}
+
+func (c *Client) ViewerLogin(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*ViewerLogin, error) {
+	vars := map[string]any{}
+
+	var res ViewerLogin
+	if err := c.Client.PostOperation(ctx, ViewerLoginOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ViewerFragmentsDocument = `query ViewerFragments {
+	viewer {
+		... on User {
+			id
+		}
+		settings {
+			theme
+			__typename
+		}
+		__typename
+	}
+	other: viewer {
+		... UserLogin
+		__typename
+		_gqlgencId: id
+	}
+}
+fragment UserLogin on User {
+	id: login
+	__typename
+	_gqlgencId: id
+}
+`
+const ViewerFragmentsDocumentHash = "c8ed57582bf246d9c2ef0e72a3f940bfaa097d1ef9fb0b1df83fad7f9650b434"
+
+var ViewerFragmentsOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "ViewerFragments",
+	Document:     ViewerFragmentsDocument,
+	DocumentHash: ViewerFragmentsDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "queries/queries.graphql",
+}
+
+func (c *Client) ViewerFragments(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*ViewerFragments, error) {
+	vars := map[string]any{}
+
+	var res ViewerFragments
+	if err := c.Client.PostOperation(ctx, ViewerFragmentsOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+var DocumentOperationNames = map[string]string{
+	ViewerDocument:          "Viewer",
+	SearchDocument:          "Search",
+	UpdateTodoDocument:      "UpdateTodo",
+	ViewerLoginDocument:     "ViewerLogin",
+	ViewerFragmentsDocument: "ViewerFragments",
+}
@@ -0,0 +1,45 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package generated
+
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
+type SearchResult interface {
+	IsSearchResult()
+}
+
+type Mutation struct {
+}
+
+type Query struct {
+}
+
+type Settings struct {
+	Theme string `json:"theme"`
+}
+
+type Todo struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+func (Todo) IsNode()            {}
+func (this Todo) GetID() string { return this.ID }
+
+func (Todo) IsSearchResult() {}
+
+type User struct {
+	ID       string    `json:"id"`
+	Login    string    `json:"login"`
+	Settings *Settings `json:"settings"`
+	Todos    []*Todo   `json:"todos"`
+}
+
+func (User) IsNode()            {}
+func (this User) GetID() string { return this.ID }
+
+func (User) IsSearchResult() {}
@@ -0,0 +1,279 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type DeferClient interface {
+	Viewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*Viewer, error]
+	Login(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Login, error)
+}
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) DeferClient {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type Profile struct {
+	Bio     *string "json:\"bio,omitempty\" graphql:\"bio\""
+	Company *string "json:\"company,omitempty\" graphql:\"company\""
+}
+
+func (t *Profile) GetBio() *string {
+	if t == nil {
+		t = &Profile{}
+	}
+	return t.Bio
+}
+func (t *Profile) GetCompany() *string {
+	if t == nil {
+		t = &Profile{}
+	}
+	return t.Company
+}
+
+type Viewer_Viewer_Profile struct {
+	clientv2.DeferredFragment
+	Bio     *string "json:\"bio,omitempty\" graphql:\"bio\""
+	Company *string "json:\"company,omitempty\" graphql:\"company\""
+}
+
+func (t *Viewer_Viewer_Profile) GetBio() *string {
+	if t == nil {
+		t = &Viewer_Viewer_Profile{}
+	}
+	return t.Bio
+}
+func (t *Viewer_Viewer_Profile) GetCompany() *string {
+	if t == nil {
+		t = &Viewer_Viewer_Profile{}
+	}
+	return t.Company
+}
+
+type Viewer_Viewer_Deferred_Repositories struct {
+	ID   string "json:\"id\" graphql:\"id\""
+	Name string "json:\"name\" graphql:\"name\""
+}
+
+func (t *Viewer_Viewer_Deferred_Repositories) GetID() string {
+	if t == nil {
+		t = &Viewer_Viewer_Deferred_Repositories{}
+	}
+	return t.ID
+}
+func (t *Viewer_Viewer_Deferred_Repositories) GetName() string {
+	if t == nil {
+		t = &Viewer_Viewer_Deferred_Repositories{}
+	}
+	return t.Name
+}
+
+type Viewer_Viewer_Deferred struct {
+	clientv2.DeferredFragment
+	Repositories []*Viewer_Viewer_Deferred_Repositories "json:\"repositories\" graphql:\"repositories\""
+}
+
+func (t *Viewer_Viewer_Deferred) GetRepositories() []*Viewer_Viewer_Deferred_Repositories {
+	if t == nil {
+		t = &Viewer_Viewer_Deferred{}
+	}
+	return t.Repositories
+}
+
+type Viewer_Viewer_Deferred2 struct {
+	clientv2.DeferredFragment
+	Bio *string "json:\"bio,omitempty\" graphql:\"bio\""
+}
+
+func (t *Viewer_Viewer_Deferred2) GetBio() *string {
+	if t == nil {
+		t = &Viewer_Viewer_Deferred2{}
+	}
+	return t.Bio
+}
+
+type Viewer_Viewer struct {
+	Deferred  Viewer_Viewer_Deferred  "graphql:\"...\" defer:\",1\""
+	Deferred2 Viewer_Viewer_Deferred2 "graphql:\"... on User\" defer:\",2\""
+	ID        string                  "json:\"id\" graphql:\"id\""
+	Login     string                  "json:\"login\" graphql:\"login\""
+	Profile   Viewer_Viewer_Profile   "graphql:\"... on User\" defer:\"profile\""
+}
+
+func (t *Viewer_Viewer) GetDeferred() *Viewer_Viewer_Deferred {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return &t.Deferred
+}
+func (t *Viewer_Viewer) GetDeferred2() *Viewer_Viewer_Deferred2 {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return &t.Deferred2
+}
+func (t *Viewer_Viewer) GetID() string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.ID
+}
+func (t *Viewer_Viewer) GetLogin() string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Login
+}
+func (t *Viewer_Viewer) GetProfile() *Viewer_Viewer_Profile {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return &t.Profile
+}
+
+type Login_Viewer struct {
+	Login string "json:\"login\" graphql:\"login\""
+}
+
+func (t *Login_Viewer) GetLogin() string {
+	if t == nil {
+		t = &Login_Viewer{}
+	}
+	return t.Login
+}
+
+type Viewer struct {
+	Viewer Viewer_Viewer "json:\"viewer\" graphql:\"viewer\""
+}
+
+func (t *Viewer) GetViewer() *Viewer_Viewer {
+	if t == nil {
+		t = &Viewer{}
+	}
+	return &t.Viewer
+}
+
+type Login struct {
+	Viewer Login_Viewer "json:\"viewer\" graphql:\"viewer\""
+}
+
+func (t *Login) GetViewer() *Login_Viewer {
+	if t == nil {
+		t = &Login{}
+	}
+	return &t.Viewer
+}
+
+const ViewerDocument = `query Viewer {
+	viewer {
+		id
+		login
+		... Profile @defer(label: "profile")
+		... @defer {
+			repositories @stream(initialCount: 1) {
+				id
+				name
+			}
+		}
+		... on User @defer {
+			bio
+		}
+	}
+}
+fragment Profile on User {
+	bio
+	company
+}
+`
+const ViewerDocumentHash = "1c9f1a32fc8965fd53a6c2cff901219e9081929242b8ef3d5acdcbdc367d93fd"
+
+var ViewerOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Viewer",
+	Document:     ViewerDocument,
+	DocumentHash: ViewerDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "queries/viewer.graphql",
+}
+
+func (c *Client) Viewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*Viewer, error] {
+	vars := map[string]any{}
+
+	return func(yield func(*Viewer, error) bool) {
+		stream, err := c.Client.PostIncrementalOperation(ctx, ViewerOperation, vars, interceptors...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer stream.Close()
+
+		// deferred and streamed results are merged into res, so every iteration yields the same value
+		var res Viewer
+		for {
+			err := stream.Next(&res)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil && !c.Client.ParseDataWhenErrors {
+				if !yield(nil, err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(&res, err) {
+				return
+			}
+		}
+	}
+}
+
+const LoginDocument = `query Login {
+	viewer {
+		login
+	}
+}
+`
+const LoginDocumentHash = "5b5e316416c9987833a5de48f705febd923c70029c101cb0b90cbaafcb9a8cae"
+
+var LoginOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Login",
+	Document:     LoginDocument,
+	DocumentHash: LoginDocumentHash,
+	RootFields:   []string{"viewer"},
+	SourceFile:   "queries/viewer.graphql",
+}
+
+func (c *Client) Login(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Login, error) {
+	vars := map[string]any{}
+
+	var res Login
+	if err := c.Client.PostOperation(ctx, LoginOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+var DocumentOperationNames = map[string]string{
+	ViewerDocument: "Viewer",
+	LoginDocument:  "Login",
+}
@@ -0,0 +1,19 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package generated
+
+type Query struct {
+}
+
+type Repository struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type User struct {
+	ID           string        `json:"id"`
+	Login        string        `json:"login"`
+	Bio          *string       `json:"bio,omitempty"`
+	Company      *string       `json:"company,omitempty"`
+	Repositories []*Repository `json:"repositories"`
+}
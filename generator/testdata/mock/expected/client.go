@@ -0,0 +1,362 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type MessagesClient interface {
+	Messages(ctx context.Context, room string, interceptors ...clientv2.RequestInterceptor) (*Messages, error)
+	OnMessageAdded(ctx context.Context, room string, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*OnMessageAdded, error]
+	MessagesOfRooms(ctx context.Context, ctx_ string, interceptors_ string, io_ string, interceptors ...clientv2.RequestInterceptor) (*MessagesOfRooms, error)
+	OnMessageAddedToRoom(ctx context.Context, errors_ string, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*OnMessageAddedToRoom, error]
+}
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) MessagesClient {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type Messages_Messages struct {
+	ID   string "json:\"id\" graphql:\"id\""
+	Text string "json:\"text\" graphql:\"text\""
+}
+
+func (t *Messages_Messages) GetID() string {
+	if t == nil {
+		t = &Messages_Messages{}
+	}
+	return t.ID
+}
+func (t *Messages_Messages) GetText() string {
+	if t == nil {
+		t = &Messages_Messages{}
+	}
+	return t.Text
+}
+
+type OnMessageAdded_MessageAdded struct {
+	CreatedBy *string "json:\"createdBy,omitempty\" graphql:\"createdBy\""
+	ID        string  "json:\"id\" graphql:\"id\""
+	Text      string  "json:\"text\" graphql:\"text\""
+}
+
+func (t *OnMessageAdded_MessageAdded) GetCreatedBy() *string {
+	if t == nil {
+		t = &OnMessageAdded_MessageAdded{}
+	}
+	return t.CreatedBy
+}
+func (t *OnMessageAdded_MessageAdded) GetID() string {
+	if t == nil {
+		t = &OnMessageAdded_MessageAdded{}
+	}
+	return t.ID
+}
+func (t *OnMessageAdded_MessageAdded) GetText() string {
+	if t == nil {
+		t = &OnMessageAdded_MessageAdded{}
+	}
+	return t.Text
+}
+
+type MessagesOfRooms_First struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *MessagesOfRooms_First) GetID() string {
+	if t == nil {
+		t = &MessagesOfRooms_First{}
+	}
+	return t.ID
+}
+
+type MessagesOfRooms_Second struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *MessagesOfRooms_Second) GetID() string {
+	if t == nil {
+		t = &MessagesOfRooms_Second{}
+	}
+	return t.ID
+}
+
+type MessagesOfRooms_Third struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *MessagesOfRooms_Third) GetID() string {
+	if t == nil {
+		t = &MessagesOfRooms_Third{}
+	}
+	return t.ID
+}
+
+type OnMessageAddedToRoom_MessageAdded struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *OnMessageAddedToRoom_MessageAdded) GetID() string {
+	if t == nil {
+		t = &OnMessageAddedToRoom_MessageAdded{}
+	}
+	return t.ID
+}
+
+type Messages struct {
+	Messages []*Messages_Messages "json:\"messages\" graphql:\"messages\""
+}
+
+func (t *Messages) GetMessages() []*Messages_Messages {
+	if t == nil {
+		t = &Messages{}
+	}
+	return t.Messages
+}
+
+type OnMessageAdded struct {
+	MessageAdded OnMessageAdded_MessageAdded "json:\"messageAdded\" graphql:\"messageAdded\""
+}
+
+func (t *OnMessageAdded) GetMessageAdded() *OnMessageAdded_MessageAdded {
+	if t == nil {
+		t = &OnMessageAdded{}
+	}
+	return &t.MessageAdded
+}
+
+type MessagesOfRooms struct {
+	First  []*MessagesOfRooms_First  "json:\"first\" graphql:\"first\""
+	Second []*MessagesOfRooms_Second "json:\"second\" graphql:\"second\""
+	Third  []*MessagesOfRooms_Third  "json:\"third\" graphql:\"third\""
+}
+
+func (t *MessagesOfRooms) GetFirst() []*MessagesOfRooms_First {
+	if t == nil {
+		t = &MessagesOfRooms{}
+	}
+	return t.First
+}
+func (t *MessagesOfRooms) GetSecond() []*MessagesOfRooms_Second {
+	if t == nil {
+		t = &MessagesOfRooms{}
+	}
+	return t.Second
+}
+func (t *MessagesOfRooms) GetThird() []*MessagesOfRooms_Third {
+	if t == nil {
+		t = &MessagesOfRooms{}
+	}
+	return t.Third
+}
+
+type OnMessageAddedToRoom struct {
+	MessageAdded OnMessageAddedToRoom_MessageAdded "json:\"messageAdded\" graphql:\"messageAdded\""
+}
+
+func (t *OnMessageAddedToRoom) GetMessageAdded() *OnMessageAddedToRoom_MessageAdded {
+	if t == nil {
+		t = &OnMessageAddedToRoom{}
+	}
+	return &t.MessageAdded
+}
+
+const MessagesDocument = `query Messages ($room: String!) {
+	messages(room: $room) {
+		id
+		text
+	}
+}
+`
+const MessagesDocumentHash = "11b2bb1d3d3c536d353f7d52ff67d244762fa00135f8b2362a3a77f4cd0ae7ee"
+
+var MessagesOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Messages",
+	Document:     MessagesDocument,
+	DocumentHash: MessagesDocumentHash,
+	RootFields:   []string{"messages"},
+	SourceFile:   "queries/messages.graphql",
+}
+
+func (c *Client) Messages(ctx context.Context, room string, interceptors ...clientv2.RequestInterceptor) (*Messages, error) {
+	vars := map[string]any{
+		"room": room,
+	}
+
+	var res Messages
+	if err := c.Client.PostOperation(ctx, MessagesOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const OnMessageAddedDocument = `subscription OnMessageAdded ($room: String!) {
+	messageAdded(room: $room) {
+		id
+		text
+		createdBy
+	}
+}
+`
+const OnMessageAddedDocumentHash = "40c34605e8f4faa8c8ee46ba517a54b4ebe765ddca484542d2e40153a1d21330"
+
+var OnMessageAddedOperation = &clientv2.Operation{
+	Type:         "subscription",
+	Name:         "OnMessageAdded",
+	Document:     OnMessageAddedDocument,
+	DocumentHash: OnMessageAddedDocumentHash,
+	RootFields:   []string{"messageAdded"},
+	SourceFile:   "queries/messages.graphql",
+}
+
+func (c *Client) OnMessageAdded(ctx context.Context, room string, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*OnMessageAdded, error] {
+	vars := map[string]any{
+		"room": room,
+	}
+
+	return func(yield func(*OnMessageAdded, error) bool) {
+		sub, err := c.Client.SubscribeOperation(ctx, OnMessageAddedOperation, vars, interceptors...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer sub.Close()
+
+		for {
+			var res OnMessageAdded
+			err := sub.Next(&res)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil && !c.Client.ParseDataWhenErrors {
+				if !yield(nil, err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(&res, err) {
+				return
+			}
+		}
+	}
+}
+
+const MessagesOfRoomsDocument = `query MessagesOfRooms ($ctx: String!, $interceptors: String!, $io: String!) {
+	first: messages(room: $ctx) {
+		id
+	}
+	second: messages(room: $interceptors) {
+		id
+	}
+	third: messages(room: $io) {
+		id
+	}
+}
+`
+const MessagesOfRoomsDocumentHash = "69da4c8b1fa7f7205a4d853954a9a5444f6b1ebe8b280a3335992a409cfe293a"
+
+var MessagesOfRoomsOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "MessagesOfRooms",
+	Document:     MessagesOfRoomsDocument,
+	DocumentHash: MessagesOfRoomsDocumentHash,
+	RootFields:   []string{"messages"},
+	SourceFile:   "queries/messages.graphql",
+}
+
+func (c *Client) MessagesOfRooms(ctx context.Context, ctx_ string, interceptors_ string, io_ string, interceptors ...clientv2.RequestInterceptor) (*MessagesOfRooms, error) {
+	vars := map[string]any{
+		"ctx":          ctx_,
+		"interceptors": interceptors_,
+		"io":           io_,
+	}
+
+	var res MessagesOfRooms
+	if err := c.Client.PostOperation(ctx, MessagesOfRoomsOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const OnMessageAddedToRoomDocument = `subscription OnMessageAddedToRoom ($errors: String!) {
+	messageAdded(room: $errors) {
+		id
+	}
+}
+`
+const OnMessageAddedToRoomDocumentHash = "996f5d2abcd34ecc28526e1c9662169d879dc659494d988bf18c9bd515b92f1f"
+
+var OnMessageAddedToRoomOperation = &clientv2.Operation{
+	Type:         "subscription",
+	Name:         "OnMessageAddedToRoom",
+	Document:     OnMessageAddedToRoomDocument,
+	DocumentHash: OnMessageAddedToRoomDocumentHash,
+	RootFields:   []string{"messageAdded"},
+	SourceFile:   "queries/messages.graphql",
+}
+
+func (c *Client) OnMessageAddedToRoom(ctx context.Context, errors_ string, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*OnMessageAddedToRoom, error] {
+	vars := map[string]any{
+		"errors": errors_,
+	}
+
+	return func(yield func(*OnMessageAddedToRoom, error) bool) {
+		sub, err := c.Client.SubscribeOperation(ctx, OnMessageAddedToRoomOperation, vars, interceptors...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer sub.Close()
+
+		for {
+			var res OnMessageAddedToRoom
+			err := sub.Next(&res)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil && !c.Client.ParseDataWhenErrors {
+				if !yield(nil, err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(&res, err) {
+				return
+			}
+		}
+	}
+}
+
+var DocumentOperationNames = map[string]string{
+	MessagesDocument:             "Messages",
+	OnMessageAddedDocument:       "OnMessageAdded",
+	MessagesOfRoomsDocument:      "MessagesOfRooms",
+	OnMessageAddedToRoomDocument: "OnMessageAddedToRoom",
+}
@@ -57,6 +57,16 @@ const CreateManyDocument = `mutation CreateMany ($todos: NewTodos!) {
 	}
 }
 `
+const CreateManyDocumentHash = "90a21ccd30f8bdd7d320751a1ecedf82de89148e5f69cedad303888bfe72bfae"
+
+var CreateManyOperation = &clientv2.Operation{
+	Type:         "mutation",
+	Name:         "CreateMany",
+	Document:     CreateManyDocument,
+	DocumentHash: CreateManyDocumentHash,
+	RootFields:   []string{"createTodos"},
+	SourceFile:   "queries/createTodos.graphql",
+}
 
 func (c *Client) CreateMany(ctx context.Context, todos NewTodos, interceptors ...clientv2.RequestInterceptor) (*CreateMany, error) {
 	vars := map[string]any{
@@ -64,7 +74,7 @@ func (c *Client) CreateMany(ctx context.Context, todos NewTodos, interceptors ..
 	}
 
 	var res CreateMany
-	if err := c.Client.Post(ctx, "CreateMany", CreateManyDocument, &res, vars, interceptors...); err != nil {
+	if err := c.Client.PostOperation(ctx, CreateManyOperation, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -0,0 +1,145 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) *Client {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type TodoFields struct {
+	ID   string "json:\"id\" graphql:\"id\""
+	Text string "json:\"text\" graphql:\"text\""
+	Done bool   "json:\"done\" graphql:\"done\""
+}
+
+func (t *TodoFields) GetID() string {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.ID
+}
+func (t *TodoFields) GetText() string {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.Text
+}
+func (t *TodoFields) GetDone() bool {
+	if t == nil {
+		t = &TodoFields{}
+	}
+	return t.Done
+}
+
+type Todos struct {
+	Todos []*TodoFields "json:\"todos\" graphql:\"todos\""
+}
+
+func (t *Todos) GetTodos() []*TodoFields {
+	if t == nil {
+		t = &Todos{}
+	}
+	return t.Todos
+}
+
+type CreateTodo struct {
+	CreateTodo *TodoFields "json:\"createTodo\" graphql:\"createTodo\""
+}
+
+func (t *CreateTodo) GetCreateTodo() *TodoFields {
+	if t == nil {
+		t = &CreateTodo{}
+	}
+	return t.CreateTodo
+}
+
+const TodosDocument = `query Todos {
+	todos {
+		... TodoFields
+	}
+}
+fragment TodoFields on Todo {
+	id
+	text
+	done
+}
+`
+const TodosDocumentHash = "d65b586ec6d2640d629120e5746f408d9a93f1478fdaa94ee2b83cbb46ec704a"
+
+var TodosOperation = &clientv2.Operation{
+	Type:         "query",
+	Name:         "Todos",
+	Document:     TodosDocument,
+	DocumentHash: TodosDocumentHash,
+	RootFields:   []string{"todos"},
+	SourceFile:   "queries/todos.graphql",
+}
+
+func (c *Client) Todos(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Todos, error) {
+	vars := map[string]any{}
+
+	var res Todos
+	if err := c.Client.PostOperation(ctx, TodosOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const CreateTodoDocument = `mutation CreateTodo ($text: String!) {
+	createTodo(text: $text) {
+		... TodoFields
+	}
+}
+fragment TodoFields on Todo {
+	id
+	text
+	done
+}
+`
+const CreateTodoDocumentHash = "b75f8638a3ee47eb23a00b40ad817b000048b0120c8936aa2bd3c32fe490efcf"
+
+var CreateTodoOperation = &clientv2.Operation{
+	Type:         "mutation",
+	Name:         "CreateTodo",
+	Document:     CreateTodoDocument,
+	DocumentHash: CreateTodoDocumentHash,
+	RootFields:   []string{"createTodo"},
+	SourceFile:   "queries/todos.graphql",
+}
+
+func (c *Client) CreateTodo(ctx context.Context, text string, interceptors ...clientv2.RequestInterceptor) (*CreateTodo, error) {
+	vars := map[string]any{
+		"text": text,
+	}
+
+	var res CreateTodo
+	if err := c.Client.PostOperation(ctx, CreateTodoOperation, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+var DocumentOperationNames = map[string]string{
+	TodosDocument:      "Todos",
+	CreateTodoDocument: "CreateTodo",
+}
@@ -0,0 +1,284 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) *Client {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type RepositoryFields struct {
+	Name        string  "json:\"name\" graphql:\"name\""
+	Description *string "json:\"description,omitempty\" graphql:\"description\""
+}
+
+func (t *RepositoryFields) GetName() string {
+	if t == nil {
+		t = &RepositoryFields{}
+	}
+	return t.Name
+}
+func (t *RepositoryFields) GetDescription() *string {
+	if t == nil {
+		t = &RepositoryFields{}
+	}
+	return t.Description
+}
+
+type Viewer_Viewer struct {
+	Login        string              "json:\"login\" graphql:\"login\""
+	Name         *string             "json:\"name,omitempty\" graphql:\"name\""
+	Repositories []*RepositoryFields "json:\"repositories\" graphql:\"repositories\""
+}
+
+func (t *Viewer_Viewer) GetLogin() string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Login
+}
+func (t *Viewer_Viewer) GetName() *string {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Name
+}
+func (t *Viewer_Viewer) GetRepositories() []*RepositoryFields {
+	if t == nil {
+		t = &Viewer_Viewer{}
+	}
+	return t.Repositories
+}
+
+type UserLogin_User struct {
+	Login string "json:\"login\" graphql:\"login\""
+}
+
+func (t *UserLogin_User) GetLogin() string {
+	if t == nil {
+		t = &UserLogin_User{}
+	}
+	return t.Login
+}
+
+type ViewerChanged_ViewerChanged struct {
+	Login string "json:\"login\" graphql:\"login\""
+}
+
+func (t *ViewerChanged_ViewerChanged) GetLogin() string {
+	if t == nil {
+		t = &ViewerChanged_ViewerChanged{}
+	}
+	return t.Login
+}
+
+type Viewer struct {
+	Viewer Viewer_Viewer "json:\"viewer\" graphql:\"viewer\""
+	errors clientv2.ResponseErrors
+}
+
+func (t *Viewer) GetViewer() *Viewer_Viewer {
+	if t == nil {
+		t = &Viewer{}
+	}
+	return &t.Viewer
+}
+
+// Errors returns the GraphQL errors of the response, which are only set when ParseDataWhenErrors is enabled.
+func (t *Viewer) Errors() clientv2.ResponseErrors {
+	if t == nil {
+		return nil
+	}
+	return t.errors
+}
+
+type UserLogin struct {
+	User   *UserLogin_User "json:\"user,omitempty\" graphql:\"user\""
+	errors clientv2.ResponseErrors
+}
+
+func (t *UserLogin) GetUser() *UserLogin_User {
+	if t == nil {
+		t = &UserLogin{}
+	}
+	return t.User
+}
+
+// Errors returns the GraphQL errors of the response, which are only set when ParseDataWhenErrors is enabled.
+func (t *UserLogin) Errors() clientv2.ResponseErrors {
+	if t == nil {
+		return nil
+	}
+	return t.errors
+}
+
+type ViewerChanged struct {
+	ViewerChanged ViewerChanged_ViewerChanged "json:\"viewerChanged\" graphql:\"viewerChanged\""
+	errors        clientv2.ResponseErrors
+}
+
+func (t *ViewerChanged) GetViewerChanged() *ViewerChanged_ViewerChanged {
+	if t == nil {
+		t = &ViewerChanged{}
+	}
+	return &t.ViewerChanged
+}
+
+// Errors returns the GraphQL errors of the response, which are only set when ParseDataWhenErrors is enabled.
+func (t *ViewerChanged) Errors() clientv2.ResponseErrors {
+	if t == nil {
+		return nil
+	}
+	return t.errors
+}
+
+const ViewerDocument = `query Viewer {
+	viewer {
+		login
+		name
+		repositories {
+			... RepositoryFields
+		}
+	}
+}
+fragment RepositoryFields on Repository {
+	name
+	description
+}
+`
+const ViewerDocumentHash = "4514068ba3d028a2da00d1e27ad36abc4808d6c955d99d357e2352b5f205394f"
+
+var ViewerOperation = &clientv2.Operation{
+	Type:            "query",
+	Name:            "Viewer",
+	Document:        ViewerDocument,
+	DocumentHash:    ViewerDocumentHash,
+	RootFields:      []string{"viewer"},
+	SourceFile:      "queries/queries.graphql",
+	SemanticNonNull: []string{"viewer", "viewer.login", "viewer.repositories", "viewer.repositories[]", "viewer.repositories[].name"},
+}
+
+func (c *Client) Viewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Viewer, error) {
+	vars := map[string]any{}
+
+	var res Viewer
+	if err := c.Client.PostOperation(ctx, ViewerOperation, &res, vars, interceptors...); err != nil {
+		// the data of a response with null semantically non-null fields is incomplete
+		if c.Client.ParseDataWhenErrors && !errors.As(err, new(*clientv2.SemanticNullError)) {
+			res.errors = clientv2.NewResponseErrors(err)
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UserLoginDocument = `query UserLogin ($login: String!) {
+	user(login: $login) {
+		login
+	}
+}
+`
+const UserLoginDocumentHash = "baa7c12bbed767ce38e7e63689d574e90dee04df6ba24be36a4a5a3a45479ffc"
+
+var UserLoginOperation = &clientv2.Operation{
+	Type:            "query",
+	Name:            "UserLogin",
+	Document:        UserLoginDocument,
+	DocumentHash:    UserLoginDocumentHash,
+	RootFields:      []string{"user"},
+	SourceFile:      "queries/queries.graphql",
+	SemanticNonNull: []string{"user.login"},
+}
+
+func (c *Client) UserLogin(ctx context.Context, login string, interceptors ...clientv2.RequestInterceptor) (*UserLogin, error) {
+	vars := map[string]any{
+		"login": login,
+	}
+
+	var res UserLogin
+	if err := c.Client.PostOperation(ctx, UserLoginOperation, &res, vars, interceptors...); err != nil {
+		// the data of a response with null semantically non-null fields is incomplete
+		if c.Client.ParseDataWhenErrors && !errors.As(err, new(*clientv2.SemanticNullError)) {
+			res.errors = clientv2.NewResponseErrors(err)
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ViewerChangedDocument = `subscription ViewerChanged {
+	viewerChanged {
+		login
+	}
+}
+`
+const ViewerChangedDocumentHash = "9e876e3add2ba1091400248b884d453bbfb86ee301a95bebf2343c9d16549ad8"
+
+var ViewerChangedOperation = &clientv2.Operation{
+	Type:            "subscription",
+	Name:            "ViewerChanged",
+	Document:        ViewerChangedDocument,
+	DocumentHash:    ViewerChangedDocumentHash,
+	RootFields:      []string{"viewerChanged"},
+	SourceFile:      "queries/queries.graphql",
+	SemanticNonNull: []string{"viewerChanged", "viewerChanged.login"},
+}
+
+func (c *Client) ViewerChanged(ctx context.Context, interceptors ...clientv2.RequestInterceptor) iter.Seq2[*ViewerChanged, error] {
+	vars := map[string]any{}
+
+	return func(yield func(*ViewerChanged, error) bool) {
+		sub, err := c.Client.SubscribeOperation(ctx, ViewerChangedOperation, vars, interceptors...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer sub.Close()
+
+		for {
+			var res ViewerChanged
+			err := sub.Next(&res)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			// the data of a result with null semantically non-null fields is incomplete
+			if err != nil && (!c.Client.ParseDataWhenErrors || errors.As(err, new(*clientv2.SemanticNullError))) {
+				if !yield(nil, err) {
+					return
+				}
+
+				continue
+			}
+
+			res.errors = clientv2.NewResponseErrors(err)
+			if !yield(&res, err) {
+				return
+			}
+		}
+	}
+}
+
+var DocumentOperationNames = map[string]string{
+	ViewerDocument:        "Viewer",
+	UserLoginDocument:     "UserLogin",
+	ViewerChangedDocument: "ViewerChanged",
+}
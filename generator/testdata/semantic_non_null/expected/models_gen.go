@@ -0,0 +1,20 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package generated
+
+type Query struct {
+}
+
+type Repository struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type Subscription struct {
+}
+
+type User struct {
+	Login        *string       `json:"login,omitempty"`
+	Name         *string       `json:"name,omitempty"`
+	Repositories []*Repository `json:"repositories,omitempty"`
+}
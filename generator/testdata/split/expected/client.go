@@ -0,0 +1,54 @@
+// Code generated by github.com/Yamashou/gqlgenc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+)
+
+type GithubClient interface {
+	ListRepositories(ctx context.Context, owner string, interceptors ...clientv2.RequestInterceptor) (*ListRepositories, error)
+	GetUser(ctx context.Context, login string, interceptors ...clientv2.RequestInterceptor) (*GetUser, error)
+	UpdateUser(ctx context.Context, login string, name *string, interceptors ...clientv2.RequestInterceptor) (*UpdateUser, error)
+}
+
+type Client struct {
+	Client *clientv2.Client
+}
+
+func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Options, interceptors ...clientv2.RequestInterceptor) GithubClient {
+	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
+}
+
+type UserFields struct {
+	ID    string  "json:\"id\" graphql:\"id\""
+	Login string  "json:\"login\" graphql:\"login\""
+	Name  *string "json:\"name,omitempty\" graphql:\"name\""
+}
+
+func (t *UserFields) GetID() string {
+	if t == nil {
+		t = &UserFields{}
+	}
+	return t.ID
+}
+func (t *UserFields) GetLogin() string {
+	if t == nil {
+		t = &UserFields{}
+	}
+	return t.Login
+}
+func (t *UserFields) GetName() *string {
+	if t == nil {
+		t = &UserFields{}
+	}
+	return t.Name
+}
+
+var DocumentOperationNames = map[string]string{
+	ListRepositoriesDocument: "ListRepositories",
+	GetUserDocument:          "GetUser",
+	UpdateUserDocument:       "UpdateUser",
+}
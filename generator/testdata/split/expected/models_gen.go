@@ -0,0 +1,22 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package generated
+
+type Mutation struct {
+}
+
+type Query struct {
+}
+
+type Repository struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Owner *User  `json:"owner"`
+}
+
+type User struct {
+	ID        string  `json:"id"`
+	Login     string  `json:"login"`
+	Name      *string `json:"name,omitempty"`
+	Followers []*User `json:"followers"`
+}
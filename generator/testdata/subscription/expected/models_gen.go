@@ -0,0 +1,15 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package generated
+
+type Message struct {
+	ID        string  `json:"id"`
+	Text      string  `json:"text"`
+	CreatedBy *string `json:"createdBy,omitempty"`
+}
+
+type Query struct {
+}
+
+type Subscription struct {
+}
@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/config"
+	"github.com/Yamashou/gqlgenc/parsequery"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// DefaultWatchDebounce is how long the inputs must stay unchanged before Watch generates again.
+const DefaultWatchDebounce = 300 * time.Millisecond
+
+// watchInterval is how often Watch looks for changes of the inputs.
+const watchInterval = 100 * time.Millisecond
+
+// Watch generates with the config file, then generates again whenever the config file, or one of the schema and
+// query files it matches, changes and stays unchanged for debounce. Files created or removed under the globs of the
+// config are changes too. Changes are detected by the modification times and sizes of the files, and the config is
+// only loaded again when the config file or a directory of the globs changes. Generation errors are written to out
+// instead of ending the watch, and Watch returns when ctx is done.
+func Watch(ctx context.Context, configFilename string, debounce time.Duration, out io.Writer) error {
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	generate := func() {
+		cfg, err := config.LoadConfig(configFilename)
+		if err == nil {
+			err = Generate(ctx, cfg)
+		}
+		if err != nil {
+			_, _ = fmt.Fprint(out, FormatError(err))
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(out, "generated %s\n", strings.Join(OutputFiles(cfg), ", "))
+	}
+
+	inputs := loadWatchedInputs(configFilename)
+	dirStamps, fileStamps := statFiles(inputs.dirs), statFiles(inputs.files)
+	generate()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if current := statFiles(inputs.dirs); !maps.Equal(current, dirStamps) {
+				inputs = loadWatchedInputs(configFilename)
+				dirStamps, fileStamps = statFiles(inputs.dirs), statFiles(inputs.files)
+				changedAt = now
+
+				continue
+			}
+
+			if current := statFiles(inputs.files); !maps.Equal(current, fileStamps) {
+				fileStamps = current
+				changedAt = now
+
+				continue
+			}
+
+			if !changedAt.IsZero() && now.Sub(changedAt) >= debounce {
+				changedAt = time.Time{}
+				generate()
+			}
+		}
+	}
+}
+
+// watchedInputs are the inputs of the generation watched by Watch.
+type watchedInputs struct {
+	// dirs are the config file, and the directories the schema and query files are matched in.
+	// The config is loaded again when one of them changes.
+	dirs []string
+	// files are the schema and query files.
+	files []string
+}
+
+// loadWatchedInputs loads the config file, and returns it with the schema and query files it matches.
+// Only the config file is watched while it cannot be loaded.
+func loadWatchedInputs(configFilename string) watchedInputs {
+	inputs := watchedInputs{dirs: []string{configFilename}}
+
+	cfg, err := config.LoadConfig(configFilename)
+	if err != nil {
+		return inputs
+	}
+
+	for _, target := range cfg.AllTargets() {
+		for _, source := range target.GQLConfig.Sources {
+			inputs.files = append(inputs.files, source.Name)
+			inputs.dirs = append(inputs.dirs, filepath.Dir(source.Name))
+		}
+
+		for _, pattern := range target.Query {
+			inputs.dirs = append(inputs.dirs, globDirs(pattern)...)
+		}
+
+		if querySources, err := parsequery.LoadQuerySources(target.Query); err == nil {
+			for _, source := range querySources {
+				inputs.files = append(inputs.files, source.Name)
+			}
+		}
+	}
+
+	slices.Sort(inputs.dirs)
+	inputs.dirs = slices.Compact(inputs.dirs)
+
+	return inputs
+}
+
+// globDirs returns the directories files matching pattern are looked for in: every directory under the root of a
+// "**" pattern, or the directories matching the directory of other patterns.
+func globDirs(pattern string) []string {
+	if root, _, ok := strings.Cut(pattern, "**"); ok {
+		var dirs []string
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && d.IsDir() {
+				dirs = append(dirs, path)
+			}
+
+			return nil
+		})
+
+		return dirs
+	}
+
+	dirs, _ := filepath.Glob(filepath.Dir(pattern))
+
+	return dirs
+}
+
+// fileStamp is the state of a file compared to detect its changes.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// statFiles returns the stamps of the files that exist, by filename.
+func statFiles(filenames []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(filenames))
+	for _, filename := range filenames {
+		if info, err := os.Stat(filename); err == nil {
+			stamps[filename] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	return stamps
+}
+
+// FormatError returns the message of a generation error, one line per GraphQL error in the form
+// "file:line: message" when err comes from the validation of the schema or the queries.
+// The lines of the errors of targets start with "target name: ".
+func FormatError(err error) string {
+	if joined := joinedErrors(err); joined != nil {
+		var b strings.Builder
+		for _, err := range joined {
+			b.WriteString(FormatError(err))
+		}
+
+		return b.String()
+	}
+
+	var targetErr *TargetError
+	if errors.As(err, &targetErr) {
+		var b strings.Builder
+		for _, line := range strings.Split(strings.TrimSuffix(FormatError(targetErr.Err), "\n"), "\n") {
+			b.WriteString("target " + targetErr.Target + ": " + line + "\n")
+		}
+
+		return b.String()
+	}
+
+	var errs gqlerror.List
+	if !errors.As(err, &errs) {
+		var gqlErr *gqlerror.Error
+		if !errors.As(err, &gqlErr) {
+			return err.Error() + "\n"
+		}
+		errs = gqlerror.List{gqlErr}
+	}
+
+	var b strings.Builder
+	for _, gqlErr := range errs {
+		if filename, _ := gqlErr.Extensions["file"].(string); filename != "" {
+			b.WriteString(filename)
+			if len(gqlErr.Locations) > 0 {
+				fmt.Fprintf(&b, ":%d", gqlErr.Locations[0].Line)
+			}
+			b.WriteString(": ")
+		}
+		b.WriteString(gqlErr.Message)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// joinedErrors returns the errors joined by err, or by an error it wraps, and nil when there are none.
+// GraphQL error lists and the errors of targets are not split.
+func joinedErrors(err error) []error {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err := err.(type) {
+		case *TargetError, gqlerror.List:
+			return nil
+		case interface{ Unwrap() []error }:
+			return err.Unwrap()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package generator_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/generator"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func (s *Suite) TestWatch() {
+	s.useDirForTest(filepath.Join("testdata", "persisted_query_manifest"))
+	s.Require().NoError(os.RemoveAll(actual))
+	s.T().Cleanup(func() { _ = os.RemoveAll(actual) })
+
+	broken := filepath.Join("queries", "watch_broken.graphql")
+	s.T().Cleanup(func() { _ = os.Remove(broken) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error)
+	go func() { done <- generator.Watch(ctx, "gqlgenc.yml", 50*time.Millisecond, &out) }()
+
+	generated := func(n int) func() bool {
+		return func() bool { return strings.Count(out.String(), "generated ") == n }
+	}
+
+	s.Eventually(generated(1), 10*time.Second, 50*time.Millisecond)
+	s.FileExists(filepath.Join(actual, "client.go"))
+
+	s.Require().NoError(os.WriteFile(broken, []byte("query WatchBroken {\n  unknownField\n}\n"), 0o644))
+	s.Eventually(func() bool {
+		return strings.Contains(out.String(), "queries/watch_broken.graphql:2: ")
+	}, 10*time.Second, 50*time.Millisecond, out.String())
+
+	s.Require().NoError(os.Remove(broken))
+	s.Eventually(generated(2), 10*time.Second, 50*time.Millisecond, out.String())
+
+	cancel()
+	s.Require().NoError(<-done)
+}
+
+func TestFormatError(t *testing.T) {
+	t.Parallel()
+
+	err := gqlerror.List{
+		gqlerror.ErrorPosf(&ast.Position{Line: 3, Column: 5, Src: &ast.Source{Name: "queries/user.graphql"}}, "Cannot query field \"name\" on type \"User\"."),
+		gqlerror.Errorf("no position"),
+	}
+	require.Equal(t, "queries/user.graphql:3: Cannot query field \"name\" on type \"User\".\nno position\n", generator.FormatError(fmt.Errorf(": %w", err)))
+	require.Equal(t, "load failed\n", generator.FormatError(errors.New("load failed")))
+
+	// wrapped errors keep the lines of the targets and the positions
+	targets := errors.Join(&generator.TargetError{Target: "users", Err: err}, &generator.TargetError{Target: "todos", Err: errors.New("load failed")})
+	require.Equal(t, "target users: queries/user.graphql:3: Cannot query field \"name\" on type \"User\".\ntarget users: no position\ntarget todos: load failed\n", generator.FormatError(fmt.Errorf("generate: %w", targets)))
+}
@@ -36,6 +36,7 @@ import (
 	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // Reference: https://blog.gopheracademy.com/advent-2017/custom-json-unmarshaler-for-graphql-client/
@@ -415,3 +416,75 @@ func unmarshalValue(value json.Token, v reflect.Value) error {
 
 	return nil
 }
+
+// ValueAtPath returns the value addressed by a GraphQL response path in the
+// GraphQL query data structure pointed to by v. Object keys are looked up by
+// GraphQL name, including the fields of fragments and embedded structs.
+// It reports false when the path does not exist, for example because a
+// parent object is null.
+//
+// The returned value is addressable, so it can be used to merge incremental
+// results delivered by @defer and @stream into v.
+func ValueAtPath(v any, path ast.Path) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for _, elem := range path {
+		rv = indirect(rv)
+		if !rv.IsValid() {
+			return reflect.Value{}, false
+		}
+
+		switch elem := elem.(type) {
+		case ast.PathName:
+			if rv.Kind() != reflect.Struct {
+				return reflect.Value{}, false
+			}
+			rv = fieldByGraphQLNameInFragments(rv, string(elem))
+			if !rv.IsValid() {
+				return reflect.Value{}, false
+			}
+		case ast.PathIndex:
+			if rv.Kind() != reflect.Slice || int(elem) < 0 || rv.Len() <= int(elem) {
+				return reflect.Value{}, false
+			}
+			rv = rv.Index(int(elem))
+		}
+	}
+
+	return rv, true
+}
+
+// indirect follows pointers and interfaces, or returns invalid reflect.Value if one of them is nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// fieldByGraphQLNameInFragments is like fieldByGraphQLName, but also
+// looks into the GraphQL fragments and embedded structs of struct v.
+func fieldByGraphQLNameInFragments(v reflect.Value, name string) reflect.Value {
+	if f := fieldByGraphQLName(v, name); f.IsValid() {
+		return f
+	}
+
+	for i := range v.NumField() {
+		sf := v.Type().Field(i)
+		if sf.PkgPath != "" || (!isGraphQLFragment(sf) && !sf.Anonymous) {
+			continue
+		}
+		fv := indirect(v.Field(i))
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+		if f := fieldByGraphQLNameInFragments(fv, name); f.IsValid() {
+			return f
+		}
+	}
+
+	return reflect.Value{}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Yamashou/gqlgenc/graphqljson"
 	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 func TestUnmarshalGraphQL(t *testing.T) {
@@ -714,3 +715,39 @@ func TestUnmarshalGQL_pointerArrayReset(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestValueAtPath(t *testing.T) {
+	t.Parallel()
+	type query struct {
+		Viewer *struct {
+			Login    string `graphql:"login"`
+			UserInfo struct {
+				Repositories []struct {
+					Name string `graphql:"name"`
+				} `graphql:"repositories"`
+			} `graphql:"... on User"`
+		} `graphql:"viewer"`
+	}
+	var got query
+	err := graphqljson.UnmarshalData([]byte(`{"viewer": {"login": "a", "repositories": [{"name": "r0"}, {"name": "r1"}]}}`), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, ok := graphqljson.ValueAtPath(&got, ast.Path{ast.PathName("viewer"), ast.PathName("repositories"), ast.PathIndex(1), ast.PathName("name")})
+	if !ok {
+		t.Fatal("path not found")
+	}
+	if v.String() != "r1" {
+		t.Errorf("got %q, want %q", v.String(), "r1")
+	}
+
+	if _, ok := graphqljson.ValueAtPath(&got, ast.Path{ast.PathName("viewer"), ast.PathName("repositories"), ast.PathIndex(2)}); ok {
+		t.Error("out of range index should not be found")
+	}
+
+	got.Viewer = nil
+	if _, ok := graphqljson.ValueAtPath(&got, ast.Path{ast.PathName("viewer"), ast.PathName("login")}); ok {
+		t.Error("field of null object should not be found")
+	}
+}
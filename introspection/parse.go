@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
 )
 
 func ParseIntrospectionQuery(url string, query Query) *ast.SchemaDocument {
@@ -24,9 +25,11 @@ func ParseIntrospectionQuery(url string, query Query) *ast.SchemaDocument {
 }
 
 type parser struct {
-	sharedPosition                *ast.Position
-	typeMap                       map[string]*FullType
-	deprecatedDirectiveDefinition *ast.DirectiveDefinition
+	sharedPosition                 *ast.Position
+	typeMap                        map[string]*FullType
+	deprecatedDirectiveDefinition  *ast.DirectiveDefinition
+	specifiedByDirectiveDefinition *ast.DirectiveDefinition
+	oneOfDirectiveDefinition       *ast.DirectiveDefinition
 }
 
 func (p parser) parseIntrospectionQuery(query Query) *ast.SchemaDocument {
@@ -41,6 +44,8 @@ func (p parser) parseIntrospectionQuery(query Query) *ast.SchemaDocument {
 		doc.Directives = append(doc.Directives, p.parseDirectiveDefinition(directiveValue))
 	}
 	p.deprecatedDirectiveDefinition = doc.Directives.ForName("deprecated")
+	p.specifiedByDirectiveDefinition = doc.Directives.ForName("specifiedBy")
+	p.oneOfDirectiveDefinition = doc.Directives.ForName("oneOf")
 
 	for _, typeVale := range p.typeMap {
 		doc.Definitions = append(doc.Definitions, p.parseTypeSystemDefinition(typeVale))
@@ -65,6 +70,12 @@ func (p parser) parseSchemaDefinition(query Query, typeMap map[string]*FullType)
 		)
 	}
 
+	if query.Schema.SubscriptionType != nil {
+		def.OperationTypes = append(def.OperationTypes,
+			p.parseOperationTypeDefinitionForSubscription(typeMap[*query.Schema.SubscriptionType.Name]),
+		)
+	}
+
 	return &def
 }
 
@@ -86,6 +97,15 @@ func (p parser) parseOperationTypeDefinitionForMutation(fullType *FullType) *ast
 	return &op
 }
 
+func (p parser) parseOperationTypeDefinitionForSubscription(fullType *FullType) *ast.OperationTypeDefinition {
+	var op ast.OperationTypeDefinition
+	op.Operation = ast.Subscription
+	op.Type = *fullType.Name
+	op.Position = p.sharedPosition
+
+	return &op
+}
+
 func (p parser) parseDirectiveDefinition(directiveValue *DirectiveType) *ast.DirectiveDefinition {
 	args := make(ast.ArgumentDefinitionList, 0, len(directiveValue.Args))
 	for _, arg := range directiveValue.Args {
@@ -98,11 +118,12 @@ func (p parser) parseDirectiveDefinition(directiveValue *DirectiveType) *ast.Dir
 	}
 
 	return &ast.DirectiveDefinition{
-		Description: pointerString(directiveValue.Description),
-		Name:        directiveValue.Name,
-		Arguments:   args,
-		Locations:   locations,
-		Position:    p.sharedPosition,
+		Description:  pointerString(directiveValue.Description),
+		Name:         directiveValue.Name,
+		Arguments:    args,
+		Locations:    locations,
+		IsRepeatable: directiveValue.IsRepeatable,
+		Position:     p.sharedPosition,
 	}
 }
 
@@ -122,7 +143,7 @@ func (p parser) parseObjectFields(typeVale *FullType) ast.FieldList {
 			Arguments:   args,
 			Type:        typ,
 			Position:    p.sharedPosition,
-			Directives:  p.buildDeprecatedDirective(field),
+			Directives:  p.buildDeprecatedDirective(field.IsDeprecated, field.DeprecationReason, ast.LocationFieldDefinition),
 		}
 		fieldList = append(fieldList, fieldDefinition)
 	}
@@ -133,12 +154,14 @@ func (p parser) parseObjectFields(typeVale *FullType) ast.FieldList {
 func (p parser) parseInputObjectFields(typeVale *FullType) ast.FieldList {
 	fieldList := make(ast.FieldList, 0, len(typeVale.InputFields))
 	for _, field := range typeVale.InputFields {
-		typ := p.getType(&field.Type)
+		inputValue := p.buildInputValue(field)
 		fieldDefinition := &ast.FieldDefinition{
-			Description: pointerString(field.Description),
-			Name:        field.Name,
-			Type:        typ,
-			Position:    p.sharedPosition,
+			Description:  inputValue.Description,
+			Name:         inputValue.Name,
+			DefaultValue: inputValue.DefaultValue,
+			Type:         inputValue.Type,
+			Directives:   p.buildDeprecatedDirective(field.IsDeprecated, field.DeprecationReason, ast.LocationInputFieldDefinition),
+			Position:     p.sharedPosition,
 		}
 		fieldList = append(fieldList, fieldDefinition)
 	}
@@ -206,6 +229,7 @@ func (p parser) parseInputObjectTypeDefinition(typeVale *FullType) *ast.Definiti
 		Name:        pointerString(typeVale.Name),
 		Interfaces:  interfaces,
 		Fields:      fieldList,
+		Directives:  p.buildOneOfDirective(typeVale),
 		Position:    p.sharedPosition,
 		BuiltIn:     false,
 	}
@@ -233,6 +257,7 @@ func (p parser) parseEnumTypeDefinition(typeVale *FullType) *ast.Definition {
 		enumValue := &ast.EnumValueDefinition{
 			Description: pointerString(enum.Description),
 			Name:        enum.Name,
+			Directives:  p.buildDeprecatedDirective(enum.IsDeprecated, enum.DeprecationReason, ast.LocationEnumValue),
 			Position:    p.sharedPosition,
 		}
 		enums = append(enums, enumValue)
@@ -253,6 +278,7 @@ func (p parser) parseScalarTypeExtension(typeVale *FullType) *ast.Definition {
 		Kind:        ast.Scalar,
 		Description: pointerString(typeVale.Description),
 		Name:        pointerString(typeVale.Name),
+		Directives:  p.buildSpecifiedByDirective(typeVale),
 		Position:    p.sharedPosition,
 		BuiltIn:     builtInScalar(typeVale),
 	}
@@ -284,11 +310,7 @@ func (p parser) buildInputValue(input *InputValue) *ast.ArgumentDefinition {
 
 	var defaultValue *ast.Value
 	if input.DefaultValue != nil {
-		defaultValue = &ast.Value{
-			Raw:      pointerString(input.DefaultValue),
-			Kind:     p.parseValueKind(typ),
-			Position: p.sharedPosition,
-		}
+		defaultValue = p.parseDefaultValue(*input.DefaultValue, typ)
 	}
 
 	return &ast.ArgumentDefinition{
@@ -296,6 +318,7 @@ func (p parser) buildInputValue(input *InputValue) *ast.ArgumentDefinition {
 		Name:         input.Name,
 		DefaultValue: defaultValue,
 		Type:         typ,
+		Directives:   p.buildDeprecatedDirective(input.IsDeprecated, input.DeprecationReason, ast.LocationArgumentDefinition),
 		Position:     p.sharedPosition,
 	}
 }
@@ -324,15 +347,43 @@ func (p parser) getType(typeRef *TypeRef) *ast.Type {
 	return ast.NamedType(pointerString(typeRef.Name), p.sharedPosition)
 }
 
-func (p parser) buildDeprecatedDirective(field *FieldValue) ast.DirectiveList {
+// parseDefaultValue parses a default value, which introspection returns as a GraphQL literal such as "\"text\""
+// or "{limit: 10}". A literal that cannot be parsed is kept as it is.
+func (p parser) parseDefaultValue(literal string, typ *ast.Type) *ast.Value {
+	document, err := gqlparser.ParseQuery(&ast.Source{Input: "{f(v: " + literal + ")}"})
+	if err == nil && len(document.Operations) == 1 && len(document.Operations[0].SelectionSet) == 1 {
+		if field, ok := document.Operations[0].SelectionSet[0].(*ast.Field); ok && len(field.Arguments) == 1 {
+			value := field.Arguments[0].Value
+			setPosition(value, p.sharedPosition)
+
+			return value
+		}
+	}
+
+	return &ast.Value{
+		Raw:      literal,
+		Kind:     p.parseValueKind(typ),
+		Position: p.sharedPosition,
+	}
+}
+
+func setPosition(value *ast.Value, position *ast.Position) {
+	value.Position = position
+	for _, child := range value.Children {
+		child.Position = position
+		setPosition(child.Value, position)
+	}
+}
+
+func (p parser) buildDeprecatedDirective(isDeprecated bool, reason *string, location ast.DirectiveLocation) ast.DirectiveList {
 	var directives ast.DirectiveList
-	if field.IsDeprecated {
+	if isDeprecated {
 		var arguments ast.ArgumentList
-		if field.DeprecationReason != nil {
+		if reason != nil {
 			arguments = append(arguments, &ast.Argument{
 				Name: "reason",
 				Value: &ast.Value{
-					Raw:      *field.DeprecationReason,
+					Raw:      *reason,
 					Kind:     ast.StringValue,
 					Position: p.sharedPosition,
 				},
@@ -345,7 +396,7 @@ func (p parser) buildDeprecatedDirective(field *FieldValue) ast.DirectiveList {
 			Position:         p.sharedPosition,
 			ParentDefinition: nil,
 			Definition:       p.deprecatedDirectiveDefinition,
-			Location:         ast.LocationVariableDefinition,
+			Location:         location,
 		}
 		directives = append(directives, deprecatedDirective)
 	}
@@ -353,6 +404,41 @@ func (p parser) buildDeprecatedDirective(field *FieldValue) ast.DirectiveList {
 	return directives
 }
 
+func (p parser) buildSpecifiedByDirective(typeVale *FullType) ast.DirectiveList {
+	if typeVale.SpecifiedByURL == nil {
+		return nil
+	}
+
+	return ast.DirectiveList{{
+		Name: "specifiedBy",
+		Arguments: ast.ArgumentList{{
+			Name: "url",
+			Value: &ast.Value{
+				Raw:      *typeVale.SpecifiedByURL,
+				Kind:     ast.StringValue,
+				Position: p.sharedPosition,
+			},
+			Position: p.sharedPosition,
+		}},
+		Position:   p.sharedPosition,
+		Definition: p.specifiedByDirectiveDefinition,
+		Location:   ast.LocationScalar,
+	}}
+}
+
+func (p parser) buildOneOfDirective(typeVale *FullType) ast.DirectiveList {
+	if !typeVale.IsOneOf {
+		return nil
+	}
+
+	return ast.DirectiveList{{
+		Name:       "oneOf",
+		Position:   p.sharedPosition,
+		Definition: p.oneOfDirectiveDefinition,
+		Location:   ast.LocationInputObject,
+	}}
+}
+
 func (p parser) parseValueKind(typ *ast.Type) ast.ValueKind {
 	typName := typ.Name()
 
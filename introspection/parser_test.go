@@ -1,11 +1,16 @@
 package introspection
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/validator"
 )
 
 func TestParseIntrospectionQuery_Parse(t *testing.T) {
@@ -40,15 +45,167 @@ func TestParseIntrospectionQuery_Parse(t *testing.T) {
 	}
 }
 
+func TestParseIntrospectionQuery_Golden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{"subscription, repeatable, oneOf, specifiedBy and deprecated arguments", "testdata/introspection_result_full.json", "testdata/introspection_result_full.graphql"},
+	}
+
+	for _, testCase := range tests {
+		test := testCase
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			query := readQueryResult(t, test.filename)
+
+			schema, err := validator.ValidateSchemaDocument(ParseIntrospectionQuery("test", query))
+			require.NoError(t, err)
+
+			var sdl bytes.Buffer
+			formatter.NewFormatter(&sdl).FormatSchema(schema)
+
+			expected, err := os.ReadFile(test.expected)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), sdl.String())
+
+			// the SDL describes the same schema, whose root operation types are left out of it by their default names
+			loaded, err := gqlparser.LoadSchema(&ast.Source{Name: test.expected, Input: sdl.String()})
+			require.NoError(t, err)
+			require.Equal(t, rootOperationTypes(loaded), rootOperationTypes(schema))
+		})
+	}
+}
+
+func rootOperationTypes(schema *ast.Schema) []string {
+	var names []string
+	for _, definition := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		if definition != nil {
+			names = append(names, definition.Name)
+		} else {
+			names = append(names, "")
+		}
+	}
+
+	return names
+}
+
 func readQueryResult(t *testing.T, filename string) Query {
 	t.Helper()
 
 	data, err := os.ReadFile(filename)
 	require.NoError(t, err)
 
+	var result struct {
+		Schema json.RawMessage `json:"__schema"`
+	}
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
 	query := Query{}
-	err = json.Unmarshal(data, &query)
+	err = json.Unmarshal(result.Schema, &query.Schema)
 	require.NoError(t, err)
 
 	return query
 }
+
+func TestFeaturesQuery_Features(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   string
+		expected Features
+	}{
+		{
+			name: "server of the current specification",
+			result: `{
+				"type": {"fields": [{"name": "specifiedByURL"}, {"name": "isOneOf"}, {"name": "inputFields", "args": [{"name": "includeDeprecated"}]}]},
+				"field": {"fields": [{"name": "args", "args": [{"name": "includeDeprecated"}]}]},
+				"directive": {"fields": [{"name": "isRepeatable"}, {"name": "args", "args": [{"name": "includeDeprecated"}]}]},
+				"inputValue": {"fields": [{"name": "isDeprecated"}, {"name": "deprecationReason"}]}
+			}`,
+			expected: Features{
+				SpecifiedByURL:          "specifiedByURL",
+				IsOneOf:                 true,
+				IsRepeatable:            true,
+				InputValueDeprecation:   true,
+				DeprecatedFieldArgs:     true,
+				DeprecatedDirectiveArgs: true,
+				DeprecatedInputFields:   true,
+			},
+		},
+		{
+			name: "graphql-js v15",
+			result: `{
+				"type": {"fields": [{"name": "specifiedByUrl"}, {"name": "inputFields", "args": []}]},
+				"field": {"fields": [{"name": "args", "args": []}]},
+				"directive": {"fields": [{"name": "isRepeatable"}, {"name": "args", "args": []}]},
+				"inputValue": {"fields": [{"name": "defaultValue"}]}
+			}`,
+			expected: Features{SpecifiedByURL: "specifiedByUrl", IsRepeatable: true},
+		},
+		{
+			name:     "introspection types not found",
+			result:   `{"type": null, "field": null, "directive": null, "inputValue": null}`,
+			expected: Features{},
+		},
+	}
+
+	for _, testCase := range tests {
+		test := testCase
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var query FeaturesQuery
+			require.NoError(t, json.Unmarshal([]byte(test.result), &query))
+			require.Equal(t, test.expected, query.Features())
+		})
+	}
+}
+
+func TestFeatures_Introspection(t *testing.T) {
+	t.Parallel()
+
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: "type Query { id: ID }"})
+
+	t.Run("fields every server has", func(t *testing.T) {
+		t.Parallel()
+		query := Features{}.Introspection()
+		require.Equal(t, Introspection, query)
+
+		for _, field := range []string{"specifiedBy", "isOneOf", "isRepeatable", "args(includeDeprecated", "inputFields(includeDeprecated", "isDeprecated\n      deprecationReason\n    }"} {
+			require.NotContains(t, query, field)
+		}
+		_, err := gqlparser.LoadQuery(schema, query)
+		require.Nil(t, err)
+	})
+
+	t.Run("all the features", func(t *testing.T) {
+		t.Parallel()
+		query := Features{
+			SpecifiedByURL:          "specifiedByURL",
+			IsOneOf:                 true,
+			IsRepeatable:            true,
+			InputValueDeprecation:   true,
+			DeprecatedFieldArgs:     true,
+			DeprecatedDirectiveArgs: true,
+			DeprecatedInputFields:   true,
+		}.Introspection()
+
+		for _, field := range []string{"specifiedByURL", "isOneOf", "isRepeatable", "args(includeDeprecated: true)", "inputFields(includeDeprecated: true)"} {
+			require.Contains(t, query, field)
+		}
+		_, err := gqlparser.LoadQuery(schema, query)
+		require.Nil(t, err)
+	})
+
+	t.Run("features query", func(t *testing.T) {
+		t.Parallel()
+		_, err := gqlparser.LoadQuery(schema, FeaturesIntrospection)
+		require.Nil(t, err)
+	})
+}
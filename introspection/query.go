@@ -1,5 +1,8 @@
 package introspection
 
+import "strings"
+
+// Introspection is the introspection query of the fields every server supports.
 const Introspection = `query Query {
       __schema {
         queryType { name }
@@ -91,3 +94,77 @@ const Introspection = `query Query {
         }
       }
     }`
+
+// Features are the fields of the introspection types that a server supports beyond the ones every server has.
+// Servers implementing an older GraphQL specification reject the whole introspection query when it asks for others.
+type Features struct {
+	// SpecifiedByURL is the name of the field of __Type with the specification of a scalar, specifiedByURL or
+	// specifiedByUrl before graphql-js v16. It is empty when the server does not support it.
+	SpecifiedByURL string
+	// IsOneOf is set when __Type has isOneOf.
+	IsOneOf bool
+	// IsRepeatable is set when __Directive has isRepeatable.
+	IsRepeatable bool
+	// InputValueDeprecation is set when __InputValue has isDeprecated and deprecationReason.
+	InputValueDeprecation bool
+	// DeprecatedFieldArgs is set when the args of __Field take includeDeprecated.
+	DeprecatedFieldArgs bool
+	// DeprecatedDirectiveArgs is set when the args of __Directive take includeDeprecated.
+	DeprecatedDirectiveArgs bool
+	// DeprecatedInputFields is set when the inputFields of __Type take includeDeprecated.
+	DeprecatedInputFields bool
+}
+
+// FeaturesIntrospection is the query finding out the Features of a server, whose result is a FeaturesQuery.
+const FeaturesIntrospection = `query Features {
+      type: __type(name: "__Type") { ...MetaType }
+      field: __type(name: "__Field") { ...MetaType }
+      directive: __type(name: "__Directive") { ...MetaType }
+      inputValue: __type(name: "__InputValue") { ...MetaType }
+    }
+
+    fragment MetaType on __Type {
+      fields {
+        name
+        args { name }
+      }
+    }`
+
+// Features returns the Features found out by FeaturesIntrospection.
+func (q FeaturesQuery) Features() Features {
+	var features Features
+	switch {
+	case q.Type.hasField("specifiedByURL"):
+		features.SpecifiedByURL = "specifiedByURL"
+	case q.Type.hasField("specifiedByUrl"):
+		features.SpecifiedByURL = "specifiedByUrl"
+	}
+	features.IsOneOf = q.Type.hasField("isOneOf")
+	features.IsRepeatable = q.Directive.hasField("isRepeatable")
+	features.InputValueDeprecation = q.InputValue.hasField("isDeprecated") && q.InputValue.hasField("deprecationReason")
+	features.DeprecatedFieldArgs = q.Field.hasArg("args", "includeDeprecated")
+	features.DeprecatedDirectiveArgs = q.Directive.hasArg("args", "includeDeprecated")
+	features.DeprecatedInputFields = q.Type.hasArg("inputFields", "includeDeprecated")
+
+	return features
+}
+
+// Introspection returns the introspection query asking for the fields of f.
+func (f Features) Introspection() string {
+	query := Introspection
+	add := func(supported bool, old, new string) {
+		if supported {
+			query = strings.Replace(query, old, new, 1)
+		}
+	}
+
+	add(f.IsRepeatable, "\n          description\n          locations\n", "\n          description\n          isRepeatable\n          locations\n")
+	add(f.DeprecatedDirectiveArgs, "\n          args {", "\n          args(includeDeprecated: true) {")
+	add(f.IsOneOf, "\n      description\n      fields(", "\n      description\n      isOneOf\n      fields(")
+	add(f.SpecifiedByURL != "", "\n      description\n", "\n      description\n      "+f.SpecifiedByURL+"\n")
+	add(f.DeprecatedFieldArgs, "\n        args {", "\n        args(includeDeprecated: true) {")
+	add(f.DeprecatedInputFields, "\n      inputFields {", "\n      inputFields(includeDeprecated: true) {")
+	add(f.InputValueDeprecation, "\n      defaultValue\n", "\n      defaultValue\n      isDeprecated\n      deprecationReason\n")
+
+	return query
+}
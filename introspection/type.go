@@ -25,13 +25,15 @@ func (fs FullTypes) NameMap() map[string]*FullType {
 }
 
 type FullType struct {
-	Kind        TypeKind
-	Name        *string
-	Description *string
-	Fields      []*FieldValue
-	InputFields []*InputValue
-	Interfaces  []*TypeRef
-	EnumValues  []*struct {
+	Kind           TypeKind
+	Name           *string
+	Description    *string
+	SpecifiedByURL *string
+	IsOneOf        bool
+	Fields         []*FieldValue
+	InputFields    []*InputValue
+	Interfaces     []*TypeRef
+	EnumValues     []*struct {
 		Name              string
 		Description       *string
 		IsDeprecated      bool
@@ -50,10 +52,12 @@ type FieldValue struct {
 }
 
 type InputValue struct {
-	Name         string
-	Description  *string
-	Type         TypeRef
-	DefaultValue *string
+	Name              string
+	Description       *string
+	Type              TypeRef
+	DefaultValue      *string
+	IsDeprecated      bool
+	DeprecationReason *string
 }
 
 type TypeRef struct {
@@ -73,8 +77,61 @@ type Query struct {
 }
 
 type DirectiveType struct {
-	Name        string
-	Description *string
-	Locations   []string
-	Args        []*InputValue
+	Name         string
+	Description  *string
+	IsRepeatable bool
+	Locations    []string
+	Args         []*InputValue
+}
+
+// FeaturesQuery is the result of FeaturesIntrospection.
+type FeaturesQuery struct {
+	Type       *MetaType
+	Field      *MetaType
+	Directive  *MetaType
+	InputValue *MetaType
+}
+
+// MetaType is an introspection type, such as __Type, with the fields the server supports.
+type MetaType struct {
+	Fields []*MetaField
+}
+
+// MetaField is a field of a MetaType, with the names of its arguments.
+type MetaField struct {
+	Name string
+	Args []*struct {
+		Name string
+	}
+}
+
+func (t *MetaType) hasField(name string) bool {
+	return t.field(name) != nil
+}
+
+func (t *MetaType) hasArg(field, name string) bool {
+	f := t.field(field)
+	if f == nil {
+		return false
+	}
+	for _, arg := range f.Args {
+		if arg.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *MetaType) field(name string) *MetaField {
+	if t == nil {
+		return nil
+	}
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+
+	return nil
 }
@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/Yamashou/gqlgenc/config"
 	"github.com/Yamashou/gqlgenc/generator"
@@ -25,15 +28,39 @@ var generateCmd = &cli.Command{
 	Usage: "generate a graphql client based on schema",
 	Flags: []cli.Flag{
 		&cli.StringFlag{Name: "configdir, c", Usage: "the directory with configuration file", Value: "."},
+		&cli.BoolFlag{Name: "watch", Usage: "generate again whenever the configuration, schema or query files change"},
+		&cli.BoolFlag{Name: "refresh-schema", Usage: "introspect the endpoints again instead of loading their schema snapshots"},
 	},
 	Action: func(ctx *cli.Context) error {
 		configDir := ctx.String("configdir")
+		if ctx.Bool("watch") {
+			if ctx.Bool("refresh-schema") {
+				_, _ = fmt.Fprintln(os.Stderr, "--refresh-schema cannot be used with --watch")
+				os.Exit(2)
+			}
+
+			cfgFile, err := config.FindConfigFile(configDir)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+				os.Exit(2)
+			}
+
+			watchCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return generator.Watch(watchCtx, cfgFile, generator.DefaultWatchDebounce, os.Stderr)
+		}
+
 		cfg, err := config.LoadConfigFromDefaultLocations(configDir)
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
 			os.Exit(2)
 		}
 
+		for _, target := range cfg.AllTargets() {
+			target.RefreshSchema = ctx.Bool("refresh-schema")
+		}
+
 		if err := generator.Generate(ctx.Context, cfg); err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
 			os.Exit(4)
@@ -43,6 +70,110 @@ var generateCmd = &cli.Command{
 	},
 }
 
+var checkCmd = &cli.Command{
+	Name:  "check",
+	Usage: "validate the configuration and queries, and fail when the generated files are not up to date",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "configdir, c", Usage: "the directory with configuration file", Value: "."},
+	},
+	Action: func(ctx *cli.Context) error {
+		configDir := ctx.String("configdir")
+		cfg, err := config.LoadConfigFromDefaultLocations(configDir)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+			os.Exit(2)
+		}
+
+		stale, err := generator.Check(ctx.Context, cfg)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+			os.Exit(4)
+		}
+
+		if len(stale) > 0 {
+			for _, filename := range stale {
+				_, _ = fmt.Fprintf(os.Stderr, "%s is not up to date\n", filename)
+			}
+			_, _ = fmt.Fprintln(os.Stderr, "run gqlgenc generate to update the generated files")
+			os.Exit(3)
+		}
+
+		return nil
+	},
+}
+
+var schemaCmd = &cli.Command{
+	Name:  "schema",
+	Usage: "work with the schema of the endpoint",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "download",
+			Usage: "introspect the endpoint with the configured headers and print its schema as SDL",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "configdir, c", Usage: "the directory with configuration file", Value: "."},
+				&cli.StringFlag{Name: "target", Usage: "the name of the target whose endpoint is introspected, when the configuration has targets"},
+				&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "the file to write the schema to instead of the standard output"},
+			},
+			Action: func(ctx *cli.Context) error {
+				cfg, err := config.LoadConfigFromDefaultLocations(ctx.String("configdir"))
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+					os.Exit(2)
+				}
+
+				target, err := findTarget(cfg, ctx.String("target"))
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+					os.Exit(2)
+				}
+
+				sdl, err := target.DownloadSchema(ctx.Context)
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "%+v\n", err.Error())
+					os.Exit(4)
+				}
+
+				if output := ctx.String("output"); output != "" {
+					return os.WriteFile(output, sdl, 0o644)
+				}
+
+				_, err = os.Stdout.Write(sdl)
+
+				return err
+			},
+		},
+	},
+}
+
+// findTarget returns the target of the config named name, or the config itself when it has no targets.
+func findTarget(cfg *config.Config, name string) (*config.Config, error) {
+	if len(cfg.Targets) == 0 {
+		if name != "" {
+			return nil, fmt.Errorf("the configuration has no targets, unset --target")
+		}
+
+		return cfg, nil
+	}
+
+	if name == "" && len(cfg.Targets) == 1 {
+		return cfg.Targets[0], nil
+	}
+
+	names := make([]string, 0, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		if target.Name == name {
+			return target, nil
+		}
+		names = append(names, target.Name)
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("set --target to one of %s", strings.Join(names, ", "))
+	}
+
+	return nil, fmt.Errorf("no target named %s in %s", name, strings.Join(names, ", "))
+}
+
 func main() {
 	app := cli.NewApp()
 	app.Name = "gqlgenc"
@@ -52,6 +183,8 @@ func main() {
 	app.Commands = []*cli.Command{
 		versionCmd,
 		generateCmd,
+		checkCmd,
+		schemaCmd,
 	}
 
 	if err := app.Run(os.Args); err != nil {
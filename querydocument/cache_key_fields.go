@@ -0,0 +1,112 @@
+package querydocument
+
+import (
+	"fmt"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// AddCacheKeyFields adds __typename, and id when the type has one, to the selection sets of the operations and
+// fragments of queryDocument, so that their responses can be normalized by clientv2.Cache.
+// queryDocument must be validated, it is validated again to resolve the added fields.
+func AddCacheKeyFields(schema *ast.Schema, queryDocument *ast.QueryDocument) error {
+	for _, operation := range queryDocument.Operations {
+		operation.SelectionSet = addCacheKeyFields(schema, nil, operation.SelectionSet, false)
+	}
+
+	for _, fragment := range queryDocument.Fragments {
+		fragment.SelectionSet = addCacheKeyFields(schema, schema.Types[fragment.TypeCondition], fragment.SelectionSet, true)
+	}
+
+	if errs := validator.Validate(schema, queryDocument); errs != nil {
+		return fmt.Errorf(": %w", errs)
+	}
+
+	return nil
+}
+
+// addCacheKeyFields adds the key fields of definition to selectionSet and to the selection sets of its fields.
+// The fields of inline fragments get only id, since __typename is added to their parent.
+func addCacheKeyFields(schema *ast.Schema, definition *ast.Definition, selectionSet ast.SelectionSet, typename bool) ast.SelectionSet {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if len(selection.SelectionSet) > 0 && selection.Definition != nil {
+				selection.SelectionSet = addCacheKeyFields(schema, schema.Types[selection.Definition.Type.Name()], selection.SelectionSet, true)
+			}
+		case *ast.InlineFragment:
+			fragmentDefinition := definition
+			if selection.TypeCondition != "" {
+				fragmentDefinition = schema.Types[selection.TypeCondition]
+			}
+			selection.SelectionSet = addCacheKeyFields(schema, fragmentDefinition, selection.SelectionSet, false)
+		}
+	}
+
+	if definition == nil || !definition.IsCompositeType() || isRootType(schema, definition) {
+		return selectionSet
+	}
+
+	if typename {
+		selectionSet = addKeyField(definition, selectionSet, "__typename", clientv2.CacheTypenameAlias)
+	}
+
+	if definition.Fields.ForName("id") != nil {
+		selectionSet = addKeyField(definition, selectionSet, "id", clientv2.CacheIDAlias)
+	}
+
+	return selectionSet
+}
+
+// addKeyField adds the field name to the selectionSet of definition unless it is selected, under alias when another
+// field is selected as name.
+func addKeyField(definition *ast.Definition, selectionSet ast.SelectionSet, name, alias string) ast.SelectionSet {
+	if hasField(definition, selectionSet, func(field *ast.Field) bool {
+		return field.Name == name && (field.Alias == name || field.Alias == alias)
+	}) {
+		return selectionSet
+	}
+
+	// the fields of all the fragments are merged into the response, whether they apply to definition or not
+	if hasField(nil, selectionSet, func(field *ast.Field) bool { return field.Alias == name && field.Name != name }) {
+		return append(selectionSet, &ast.Field{Alias: alias, Name: name})
+	}
+
+	return append(selectionSet, &ast.Field{Alias: name, Name: name})
+}
+
+func isRootType(schema *ast.Schema, definition *ast.Definition) bool {
+	return definition == schema.Query || definition == schema.Mutation || definition == schema.Subscription
+}
+
+// hasField reports whether selectionSet has a field matching match, directly or in its fragments. With a definition,
+// only the fragments that always apply to it are looked in, the ones without a type condition or on definition itself,
+// otherwise all of them are.
+func hasField(definition *ast.Definition, selectionSet ast.SelectionSet, match func(*ast.Field) bool) bool {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if match(selection) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if appliesTo(definition, selection.TypeCondition) && hasField(definition, selection.SelectionSet, match) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if selection.Definition != nil && appliesTo(definition, selection.Definition.TypeCondition) &&
+				hasField(definition, selection.Definition.SelectionSet, match) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// appliesTo reports whether a fragment with the type condition always applies to definition, or definition is nil.
+func appliesTo(definition *ast.Definition, typeCondition string) bool {
+	return definition == nil || typeCondition == "" || typeCondition == definition.Name
+}